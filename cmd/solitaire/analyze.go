@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// runAnalyze solves a range of seeded deals and writes a report per deal to
+// stdout, with a summary on stderr.
+func runAnalyze(args []string) error {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	from := fs.Int64("from", 1, "first seed to analyse")
+	to := fs.Int64("to", 100, "last seed to analyse")
+	rulesFlag := fs.String("rules", "", `house rules, e.g. "draw=3 redeals=2 empty=any"`)
+	nodes := fs.Int("nodes", solver.DefaultMaxNodes, "search budget per deal")
+	workers := fs.Int("workers", runtime.NumCPU(), "deals to solve at once")
+	format := fs.String("format", "json", "output format: json or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format %q (want json or csv)", *format)
+	}
+	if *to < *from {
+		return fmt.Errorf("seed range %d-%d is empty", *from, *to)
+	}
+
+	rules, err := game.ParseRules(*rulesFlag)
+	if err != nil {
+		return err
+	}
+	reports, err := analysis.AnalyzeDeals(context.Background(), analysis.DealOptions{
+		From:     *from,
+		To:       *to,
+		Rules:    rules,
+		MaxNodes: *nodes,
+		Workers:  *workers,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *format == "csv" {
+		err = analysis.WriteCSV(os.Stdout, reports)
+	} else {
+		err = analysis.WriteJSON(os.Stdout, reports)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Rules: %s\n", rules)
+	return analysis.WriteSummary(os.Stderr, reports)
+}
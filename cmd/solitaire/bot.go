@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/bot"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// runBot plays an external bot engine against a run of seeded deals.
+func runBot(args []string) error {
+	fs := flag.NewFlagSet("bot", flag.ContinueOnError)
+	games := fs.Int("games", 100, "number of deals to play")
+	seed := fs.Int64("seed", 1, "seed of the first deal; later deals use the following seeds")
+	rulesFlag := fs.String("rules", "", `house rules, e.g. "draw=3 redeals=2 empty=any"`)
+	maxMoves := fs.Int("max-moves", 2000, "count a game as lost after this many moves")
+	timeout := fs.Duration("timeout", 5*time.Second, "time the engine has to answer each request")
+	verbose := fs.Bool("v", false, "print the result of every game")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: solitaire bot [flags] command [args...]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	rules, err := game.ParseRules(*rulesFlag)
+	if err != nil {
+		return err
+	}
+
+	engine, err := bot.StartEngine(*timeout, fs.Arg(0), fs.Args()[1:]...)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	var sum bot.Summary
+	for i := 0; i < *games; i++ {
+		g := game.NewGameWithRules(*seed+int64(i), rules)
+		r, err := bot.Play(engine, g, *maxMoves)
+		if err != nil {
+			return fmt.Errorf("seed %d: %w", g.Seed, err)
+		}
+		sum.Add(r)
+		if *verbose {
+			fmt.Printf("seed %d: %s in %d moves\n", r.Seed, outcome(r), r.Moves)
+		}
+	}
+
+	fmt.Printf("Played %d deals (seeds %d-%d) under %s\n", sum.Games, *seed, *seed+int64(*games)-1, rules)
+	fmt.Printf("Won %d (%.1f%%)\n", sum.Won, 100*sum.WinRate())
+	fmt.Printf("Average moves: %.1f per game, %.1f per win\n", sum.AverageMoves(), sum.AverageWinMoves())
+	return nil
+}
+
+func outcome(r bot.Result) string {
+	switch {
+	case r.Won:
+		return "won"
+	case r.Resigned:
+		return "resigned"
+	}
+	return "lost"
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/records"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui"
+)
+
+// runDaily plays today's daily challenge deal.
+func runDaily(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: solitaire daily")
+	}
+	cfg, cfgErr := loadConfig()
+	m := ui.NewModel().WithConfig(cfg, cfgErr).WithDaily(time.Now())
+	st, statsErr := loadStats()
+	log, logErr := loadDailyLog()
+	dir, dirErr := records.DefaultDir()
+	return runTUI(m.WithStats(st, statsErr).WithDailyLog(log, logErr).WithRecords(dir, dirErr))
+}
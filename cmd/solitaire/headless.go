@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/headless"
+)
+
+// runHeadless plays a game from text commands on stdin, printing the board to stdout.
+func runHeadless(args []string) error {
+	fs := flag.NewFlagSet("headless", flag.ContinueOnError)
+	rulesFlag := fs.String("rules", "", `house rules, e.g. "draw=3 redeals=2 empty=any"`)
+	seed := fs.Int64("seed", 0, "deal this seed instead of a random one")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: solitaire headless [-seed n] [-rules rules] < commands\n\n%s\nFlags:\n", headless.Help)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	rules, err := game.ParseRules(*rulesFlag)
+	if err != nil {
+		return err
+	}
+
+	g := game.NewGameWithRules(*seed, rules)
+	if *seed == 0 {
+		g = game.NewGameWithRules(game.NewGame().Seed, rules)
+	}
+
+	failed, err := headless.Run(g, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d commands failed", failed)
+	}
+	return nil
+}
@@ -1,23 +1,130 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/config"
+	"github.com/solitaire-tui/solitaire-tui/internal/records"
 	"github.com/solitaire-tui/solitaire-tui/internal/ui"
 )
 
 func main() {
+	args := os.Args[1:]
+	var err error
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		switch args[0] {
+		case "replay":
+			err = runReplay(args[1:])
+		case "stats":
+			err = runStats(args[1:])
+		case "daily":
+			err = runDaily(args[1:])
+		case "headless":
+			err = runHeadless(args[1:])
+		case "bot":
+			err = runBot(args[1:])
+		case "tournament":
+			err = runTournament(args[1:])
+		case "analyze":
+			err = runAnalyze(args[1:])
+		default:
+			err = fmt.Errorf("unknown command %q", args[0])
+		}
+	} else {
+		err = runPlay(args)
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return // Usage has already been printed
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Alas, there's been an error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runPlay starts an interactive game. Flags given on the command line
+// override the settings file.
+func runPlay(args []string) error {
+	fs := flag.NewFlagSet("solitaire", flag.ContinueOnError)
+	rulesFlag := fs.String("rules", "", `house rules on top of the settings, e.g. "draw=3 redeals=2 empty=any"`)
+	seed := fs.Int64("seed", 0, "deal this seed instead of a random one")
+	difficulty := fs.String("difficulty", "", "deal a game of this difficulty: easy, medium, hard or expert")
+	practice := fs.Bool("practice", false, "practice on open deals, with every card visible; not counted in statistics")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *seed != 0 && *difficulty != "" {
+		return fmt.Errorf("-seed and -difficulty cannot be used together")
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	cfg, cfgErr := loadConfig()
+	rules, err := cfg.Rules().With(*rulesFlag)
+	if err != nil {
+		return err
+	}
+
+	m := ui.NewModel()
+	if *seed == 0 && *difficulty == "" {
+		// A game picked on the command line starts straight away
+		m = m.WithMenu()
+	}
+	m = m.WithConfig(cfg, cfgErr)
+	if set["practice"] {
+		m = m.WithPractice(*practice)
+	}
+	if *seed != 0 {
+		m = m.WithSeed(*seed)
+	}
+	if set["rules"] {
+		m = m.WithRules(rules)
+	}
+	if *difficulty != "" {
+		d, err := analysis.ParseDifficulty(*difficulty)
+		if err != nil {
+			return err
+		}
+		if m, err = m.WithDifficulty(d); err != nil {
+			return err
+		}
+	}
+	st, statsErr := loadStats()
+	log, logErr := loadDailyLog()
+	dir, dirErr := records.DefaultDir()
+	return runTUI(m.WithStats(st, statsErr).WithDailyLog(log, logErr).WithRecords(dir, dirErr))
+}
+
+// loadConfig reads the settings file in the config directory. If the file
+// cannot be used, it returns the defaults with the error, which the game shows.
+func loadConfig() (*config.Config, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return config.Default(), err
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		return config.Default(), err
+	}
+	return c, nil
+}
+
+// runTUI runs a bubbletea program full screen with mouse support.
+func runTUI(m tea.Model) error {
 	// Create program with mouse support enabled
 	p := tea.NewProgram(
-		ui.NewModel(),
+		m,
 		tea.WithAltScreen(),       // Use alternate screen buffer
 		tea.WithMouseCellMotion(), // Enable mouse support
 	)
 
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
-	}
+	_, err := p.Run()
+	return err
 }
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui"
+)
+
+// runReplay opens a recorded game in the read-only replay viewer.
+func runReplay(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: solitaire replay <game-file>")
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	g, err := game.Import(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return runTUI(ui.NewReplayModel(g))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/stats"
+)
+
+// runStats prints the saved statistics as a table or as JSON.
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print statistics as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	st, err := loadStats()
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return st.WriteJSON(os.Stdout)
+	}
+	return st.WriteText(os.Stdout)
+}
+
+// loadStats opens the statistics file in the data directory.
+func loadStats() (*stats.Stats, error) {
+	path, err := stats.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return stats.Load(path)
+}
+
+// loadDailyLog opens the daily challenge log in the data directory.
+func loadDailyLog() (*stats.DailyLog, error) {
+	path, err := stats.DefaultDailyPath()
+	if err != nil {
+		return nil, err
+	}
+	return stats.LoadDaily(path)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/bot"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// runTournament plays the built-in bots against the same seeded deals and
+// prints how they compare.
+func runTournament(args []string) error {
+	fs := flag.NewFlagSet("tournament", flag.ContinueOnError)
+	games := fs.Int("games", 1000, "number of deals each player plays")
+	seed := fs.Int64("seed", 1, "seed of the first deal; later deals use the following seeds")
+	rulesFlag := fs.String("rules", "", `house rules, e.g. "draw=3 redeals=2 empty=any"`)
+	players := fs.String("players", strings.Join(bot.BuiltInNames, ","), "comma-separated players to compare")
+	maxMoves := fs.Int("max-moves", 2000, "count a game as lost after this many moves")
+	nodes := fs.Int("nodes", solver.DefaultMaxNodes, "search budget per deal for the solver player")
+	workers := fs.Int("workers", runtime.NumCPU(), "games to play at once")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rules, err := game.ParseRules(*rulesFlag)
+	if err != nil {
+		return err
+	}
+	var entrants []bot.Entrant
+	for _, name := range strings.Split(*players, ",") {
+		e, err := bot.BuiltIn(strings.TrimSpace(name), *nodes)
+		if err != nil {
+			return err
+		}
+		entrants = append(entrants, e)
+	}
+
+	standings, err := bot.RunTournament(context.Background(), entrants, bot.TournamentOptions{
+		Seed:     *seed,
+		Games:    *games,
+		Rules:    rules,
+		MaxMoves: *maxMoves,
+		Workers:  *workers,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d deals (seeds %d-%d) under %s\n\n", *games, *seed, *seed+int64(*games)-1, rules)
+	return bot.WriteStandings(os.Stdout, standings)
+}
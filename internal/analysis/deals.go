@@ -0,0 +1,143 @@
+package analysis
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// Values of DealReport.Winnable. The solver prunes its search, so a deal it
+// finds no win for is not proven unwinnable and is reported as not found.
+const (
+	WinnableYes      = "yes"
+	WinnableNotFound = "not found"
+	WinnableUnknown  = "unknown" // The budget ran out first
+)
+
+// DealReport is the analysis of one deal.
+type DealReport struct {
+	Seed           int64    `json:"seed"`
+	Winnable       string   `json:"winnable"` // WinnableYes, WinnableNotFound or WinnableUnknown
+	SolutionLength int      `json:"solution_length,omitempty"`
+	Nodes          int      `json:"nodes"`
+	Patterns       []string `json:"patterns,omitempty"` // Only reported for deals with no win found
+}
+
+// DealOptions describe a batch of deals to analyse.
+type DealOptions struct {
+	From, To int64 // Seed range, inclusive
+	Rules    game.Rules
+	MaxNodes int // Solver budget per deal
+	Workers  int
+}
+
+// AnalyzeDeals solves every deal in the seed range across a pool of workers
+// and returns the reports in seed order. If ctx is cancelled the reports
+// finished so far are returned with the context's error.
+func AnalyzeDeals(ctx context.Context, opts DealOptions) ([]DealReport, error) {
+	if opts.To < opts.From {
+		return nil, nil
+	}
+	reports := make([]DealReport, opts.To-opts.From+1)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < max(opts.Workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reports[i] = AnalyzeDeal(ctx, opts.From+int64(i), opts.Rules, opts.MaxNodes)
+			}
+		}()
+	}
+
+	done := 0
+feed:
+	for i := range reports {
+		select {
+		case jobs <- i:
+			done++
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return reports[:done], ctx.Err()
+}
+
+// AnalyzeDeal solves the deal from seed under rules.
+func AnalyzeDeal(ctx context.Context, seed int64, rules game.Rules, maxNodes int) DealReport {
+	g := game.NewGameWithRules(seed, rules)
+	r := solver.Solve(ctx, g, solver.Options{MaxNodes: maxNodes})
+	report := DealReport{Seed: seed, Winnable: WinnableUnknown, Nodes: r.Nodes}
+	switch r.Status {
+	case solver.Solved:
+		report.Winnable = WinnableYes
+		report.SolutionLength = len(r.Moves)
+	case solver.NoWinFound:
+		report.Winnable = WinnableNotFound
+		report.Patterns = Patterns(g)
+	}
+	return report
+}
+
+// WriteJSON writes the reports as an indented JSON array.
+func WriteJSON(w io.Writer, reports []DealReport) error {
+	if reports == nil {
+		reports = []DealReport{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
+
+// WriteCSV writes the reports with a header row. Patterns are joined with ";".
+func WriteCSV(w io.Writer, reports []DealReport) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"seed", "winnable", "solution_length", "nodes", "patterns"})
+	for _, r := range reports {
+		cw.Write([]string{
+			strconv.FormatInt(r.Seed, 10),
+			r.Winnable,
+			strconv.Itoa(r.SolutionLength),
+			strconv.Itoa(r.Nodes),
+			strings.Join(r.Patterns, ";"),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSummary prints how many deals were winnable and how often each
+// pattern appeared among those the solver found no win for.
+func WriteSummary(w io.Writer, reports []DealReport) error {
+	counts := map[string]int{}
+	patterns := map[string]int{}
+	for _, r := range reports {
+		counts[r.Winnable]++
+		for _, p := range r.Patterns {
+			patterns[p]++
+		}
+	}
+	n := max(len(reports), 1)
+	pct := func(k int) float64 { return 100 * float64(k) / float64(n) }
+	yes, notFound, unknown := counts[WinnableYes], counts[WinnableNotFound], counts[WinnableUnknown]
+	_, err := fmt.Fprintf(w, "%d deals: %d winnable (%.1f%%), %d no win found (%.1f%%), %d unknown (%.1f%%)\n",
+		len(reports), yes, pct(yes), notFound, pct(notFound), unknown, pct(unknown))
+	if err != nil || notFound == 0 {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Deals with no win found matching %s %d, %s %d, %s %d\n",
+		KingBottleneck, patterns[KingBottleneck], BuriedAce, patterns[BuriedAce], DeadOnArrival, patterns[DeadOnArrival])
+	return err
+}
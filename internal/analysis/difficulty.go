@@ -0,0 +1,175 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// Difficulty is how hard a deal is to win.
+type Difficulty int
+
+const (
+	// Unrated deals could not be solved within the rating budget.
+	Unrated Difficulty = iota
+	Easy
+	Medium
+	Hard
+	Expert
+	// NoWinFound deals were searched to the end without a win. The search
+	// prunes, so they may still be winnable.
+	NoWinFound
+)
+
+// Difficulties lists the levels a deal can be picked by, easiest first.
+var Difficulties = []Difficulty{Easy, Medium, Hard, Expert}
+
+// String returns the lower-case name of the difficulty.
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "easy"
+	case Medium:
+		return "medium"
+	case Hard:
+		return "hard"
+	case Expert:
+		return "expert"
+	case NoWinFound:
+		return "no win found"
+	default:
+		return "unrated"
+	}
+}
+
+// ParseDifficulty parses the name of one of Difficulties, ignoring case.
+func ParseDifficulty(s string) (Difficulty, error) {
+	for _, d := range Difficulties {
+		if strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+	return Unrated, fmt.Errorf("unknown difficulty %q (want easy, medium, hard or expert)", s)
+}
+
+// RatingNodes is the solver budget used to rate a deal. It is small enough
+// to rate a deal while it is dealt.
+const RatingNodes = 20_000
+
+// Rating is a deal's difficulty and the measurements behind it.
+type Rating struct {
+	Difficulty     Difficulty
+	Score          float64 // Higher is harder; see Rate
+	SolutionLength int
+	Nodes          int     // Positions the solver expanded
+	Branching      float64 // Mean number of legal moves along the solution
+	AceDepth       int     // Cards covering the Aces dealt to the tableau
+	KingsDown      int     // Kings dealt face down
+}
+
+// Rate solves the deal g with a budget of maxNodes (RatingNodes if zero) and
+// rates it. The score adds up:
+//   - backtracking: log2 of the positions searched per solution move,
+//   - length: every 20 moves beyond 110,
+//   - burial: half the cards covering the Aces and each face-down King,
+//   - narrowness: how far the mean choice of moves falls below 9.
+func Rate(ctx context.Context, g *game.Game, maxNodes int) Rating {
+	if maxNodes <= 0 {
+		maxNodes = RatingNodes
+	}
+	r := Rating{AceDepth: aceDepth(g), KingsDown: kingsDown(g)}
+	res := solver.Solve(ctx, g, solver.Options{MaxNodes: maxNodes})
+	r.Nodes = res.Nodes
+	switch res.Status {
+	case solver.NoWinFound:
+		r.Difficulty = NoWinFound
+		return r
+	case solver.Unknown:
+		return r
+	}
+
+	r.SolutionLength = len(res.Moves)
+	r.Branching = branching(g, res.Moves)
+	r.Score = math.Log2(float64(r.Nodes)/float64(max(r.SolutionLength, 1))) +
+		float64(r.SolutionLength-110)/20 +
+		float64(r.AceDepth)/2 + float64(r.KingsDown) +
+		max(9-r.Branching, 0)
+
+	switch {
+	case r.Score < easyBelow:
+		r.Difficulty = Easy
+	case r.Score < mediumBelow:
+		r.Difficulty = Medium
+	case r.Score < hardBelow:
+		r.Difficulty = Hard
+	default:
+		r.Difficulty = Expert
+	}
+	return r
+}
+
+// Score thresholds between the difficulty levels.
+const (
+	easyBelow   = 5.5
+	mediumBelow = 7
+	hardBelow   = 9
+)
+
+// aceDepth counts the cards lying on top of the Aces in the tableau.
+func aceDepth(g *game.Game) int {
+	depth := 0
+	for _, t := range g.Tableaus {
+		for i, c := range t.Cards {
+			if c.Rank == game.Ace {
+				depth += len(t.Cards) - 1 - i
+			}
+		}
+	}
+	return depth
+}
+
+func kingsDown(g *game.Game) int {
+	n := 0
+	for _, t := range g.Tableaus {
+		for _, c := range t.Cards {
+			if !c.FaceUp && c.Rank == game.King {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// branching returns the mean number of legal moves met while playing moves from g.
+func branching(g *game.Game, moves []game.Move) float64 {
+	if len(moves) == 0 {
+		return 0
+	}
+	c := g.Clone()
+	total := 0
+	for _, m := range moves {
+		total += len(c.LegalMoves())
+		c.Apply(m)
+	}
+	return float64(total) / float64(len(moves))
+}
+
+// FindDeal rates the deals from seed onwards under rules and returns the first
+// one rated d. It gives up after tries deals or when ctx is cancelled.
+func FindDeal(ctx context.Context, d Difficulty, rules game.Rules, seed int64, tries int) (int64, Rating, error) {
+	for i := 0; i < tries; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, Rating{}, err
+		}
+		r := Rate(ctx, game.NewGameWithRules(seed, rules), 0)
+		if r.Difficulty == d {
+			return seed, r, nil
+		}
+		seed++
+	}
+	return 0, Rating{}, fmt.Errorf("no %s deal found in %d tries", d, tries)
+}
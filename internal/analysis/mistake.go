@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// Reasons FindMistake finds no mistake.
+var (
+	ErrDealUnwinnable = errors.New("the solver found no win from the deal")
+	ErrStillWinnable  = errors.New("the final position can still be won")
+	ErrUndecided      = errors.New("the solver could not decide the game within its budget")
+)
+
+// Mistake is the move that most likely threw a game away.
+type Mistake struct {
+	Index       int         // Position of the blunder in the move list
+	Move        game.Move   // The blunder itself
+	Alternative []game.Move // A winning line from the position before the blunder
+	Uncertain   bool        // Some later positions could not be decided, so the blunder may be later
+}
+
+// FindMistake replays moves on the deal from seed under rules and finds the
+// last position the solver can win from; the move played there is the likely
+// blunder. The positions are searched back from the end one by one, since
+// the solver prunes and a position it finds no win from may still be won.
+// That also makes the blunder likely rather than proven. Positions the
+// solver cannot decide within maxNodes are passed over and the result is
+// marked Uncertain.
+func FindMistake(ctx context.Context, seed int64, rules game.Rules, moves []game.Move, maxNodes int) (Mistake, error) {
+	// winnable solves the position after the first n moves.
+	winnable := func(n int) solver.Result {
+		g := game.NewGameWithRules(seed, rules)
+		for _, m := range moves[:n] {
+			g.Apply(m)
+		}
+		return solver.Solve(ctx, g, solver.Options{MaxNodes: maxNodes})
+	}
+
+	start := winnable(0)
+	switch start.Status {
+	case solver.NoWinFound:
+		return Mistake{}, ErrDealUnwinnable
+	case solver.Unknown:
+		return Mistake{}, ErrUndecided
+	}
+	end := winnable(len(moves))
+	switch end.Status {
+	case solver.Solved:
+		return Mistake{}, ErrStillWinnable
+	case solver.Unknown:
+		if ctx.Err() != nil {
+			return Mistake{}, ctx.Err()
+		}
+	}
+
+	uncertain := end.Status == solver.Unknown
+	for n := len(moves) - 1; n > 0; n-- {
+		r := winnable(n)
+		if ctx.Err() != nil {
+			return Mistake{}, ctx.Err()
+		}
+		switch r.Status {
+		case solver.Solved:
+			return Mistake{Index: n, Move: moves[n], Alternative: r.Moves, Uncertain: uncertain}, nil
+		case solver.Unknown:
+			uncertain = true
+		}
+	}
+	return Mistake{Index: 0, Move: moves[0], Alternative: start.Moves, Uncertain: uncertain}, nil
+}
@@ -0,0 +1,97 @@
+// Package analysis studies deals: whether they can be won, how hard they are
+// and which known traps (see solitaire_edge_cases.md) they fall into.
+package analysis
+
+import (
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// Patterns of unwinnable deals, named after solitaire_edge_cases.md.
+const (
+	// KingBottleneck: no King can be reached without playing a card, so an
+	// emptied column cannot be refilled early on.
+	KingBottleneck = "king-bottleneck"
+	// BuriedAce: an Ace lies at the bottom of a column under three or more
+	// cards, at least one of them a Queen or King.
+	BuriedAce = "buried-ace"
+	// DeadOnArrival: nothing can be played from the deal or from a first
+	// pass through the stock.
+	DeadOnArrival = "dead-on-arrival"
+)
+
+// Patterns returns the patterns g matches, in the order they are declared.
+// They describe the position as dealt, so g should be fresh.
+func Patterns(g *game.Game) []string {
+	var out []string
+	firstPass := stockFirstPass(g)
+	if !kingReachable(g, firstPass) {
+		out = append(out, KingBottleneck)
+	}
+	if buriedAce(g) {
+		out = append(out, BuriedAce)
+	}
+	if deadOnArrival(g) {
+		out = append(out, DeadOnArrival)
+	}
+	return out
+}
+
+// stockFirstPass returns the cards that show on top of the waste while the
+// stock is drawn through once without playing anything.
+func stockFirstPass(g *game.Game) []game.Card {
+	c := g.Clone()
+	var seen []game.Card
+	for len(c.Stock.Cards) > 0 {
+		c.DrawCard()
+		seen = append(seen, *c.Waste.Peek())
+	}
+	return seen
+}
+
+func kingReachable(g *game.Game, firstPass []game.Card) bool {
+	for _, t := range g.Tableaus {
+		for _, c := range t.Cards {
+			if c.FaceUp && c.Rank == game.King {
+				return true
+			}
+		}
+	}
+	for _, c := range firstPass {
+		if c.Rank == game.King {
+			return true
+		}
+	}
+	return false
+}
+
+func buriedAce(g *game.Game) bool {
+	for _, t := range g.Tableaus {
+		if len(t.Cards) < 4 || t.Cards[0].Rank != game.Ace {
+			continue
+		}
+		for _, c := range t.Cards[1:] {
+			if c.Rank >= game.Queen {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deadOnArrival reports whether no card can be played from the deal or from
+// the waste during the first pass through the stock. The tableau cannot
+// change while only drawing, so after the deal only the waste needs checking.
+func deadOnArrival(g *game.Game) bool {
+	c := g.Clone()
+	for {
+		for _, m := range c.LegalMoves() {
+			if m.Kind == game.MoveTransfer {
+				return false
+			}
+		}
+		if len(c.Stock.Cards) == 0 {
+			return true
+		}
+		c.DrawCard()
+	}
+}
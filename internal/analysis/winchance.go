@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// EstimateOptions control a win chance estimate.
+type EstimateOptions struct {
+	Samples  int   // Orderings of the hidden cards to try
+	MaxNodes int   // Solver budget per sample
+	Seed     int64 // Seed for drawing the samples
+	PerMove  bool  // Also estimate each legal move
+
+	// Progress, if set, is called with the running estimate for the position
+	// after each sample.
+	Progress func(Estimate)
+}
+
+// Estimate is a win chance measured over sampled deals.
+type Estimate struct {
+	Samples int // Samples solved
+	Wins    int // Samples the solver won
+	Unknown int // Samples the solver ran out of budget on
+}
+
+// Chance returns the fraction of samples that were won, and false if there
+// were none. Samples the solver could not decide count as lost, so the chance
+// is a lower bound.
+func (e Estimate) Chance() (float64, bool) {
+	if e.Samples == 0 {
+		return 0, false
+	}
+	return float64(e.Wins) / float64(e.Samples), true
+}
+
+// MoveEstimate is the win chance after playing a move.
+type MoveEstimate struct {
+	Move game.Move
+	Estimate
+}
+
+// WinChance estimates how likely the position in g is to be won by a player
+// who cannot see the hidden cards. Each sample deals the hidden cards into
+// their places in a random order and is solved; the estimate is the share
+// of samples won. A card is hidden if it is face down in the tableau, or in
+// the stock before the waste has first been recycled; after that the player
+// has seen every stock card go by. With PerMove set, every legal move is also
+// played on every sample and estimated the same way. g is not modified.
+func WinChance(ctx context.Context, g *game.Game, opts EstimateOptions) (Estimate, []MoveEstimate) {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	var moves []MoveEstimate
+	if opts.PerMove {
+		for _, m := range g.LegalMoves() {
+			moves = append(moves, MoveEstimate{Move: m})
+		}
+	}
+
+	var total Estimate
+	for i := 0; i < opts.Samples && ctx.Err() == nil; i++ {
+		sample := Sample(g, rng)
+		total.add(solver.Solve(ctx, sample, solver.Options{MaxNodes: opts.MaxNodes}).Status)
+		for j := range moves {
+			after := sample.Clone()
+			after.Apply(moves[j].Move)
+			moves[j].add(solver.Solve(ctx, after, solver.Options{MaxNodes: opts.MaxNodes}).Status)
+		}
+		if opts.Progress != nil {
+			opts.Progress(total)
+		}
+	}
+	return total, moves
+}
+
+func (e *Estimate) add(s solver.Status) {
+	e.Samples++
+	switch s {
+	case solver.Solved:
+		e.Wins++
+	case solver.Unknown:
+		e.Unknown++
+	}
+}
+
+// Sample returns a copy of g with its hidden cards shuffled among their
+// places, face-down cards staying face down. Everything the player can see
+// is unchanged, and so are hidden cards the player has seen before (see
+// game.Game.Seen). Nothing is hidden in an open game, so it is copied as is.
+func Sample(g *game.Game, rng *rand.Rand) *game.Game {
+	s := g.Clone()
+	if s.Open {
+		return s
+	}
+	var slots []*game.Card
+	for i := range s.Tableaus {
+		for _, c := range s.Tableaus[i].Cards {
+			if !c.FaceUp && !s.Seen(*c) {
+				slots = append(slots, c)
+			}
+		}
+	}
+	if s.Recycles == 0 {
+		for _, c := range s.Stock.Cards {
+			if !s.Seen(*c) {
+				slots = append(slots, c)
+			}
+		}
+	}
+
+	cards := make([]game.Card, len(slots))
+	for i, c := range slots {
+		cards[i] = *c
+	}
+	rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	for i, c := range slots {
+		c.Suit, c.Rank = cards[i].Suit, cards[i].Rank
+	}
+	return s
+}
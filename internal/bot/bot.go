@@ -0,0 +1,112 @@
+// Package bot lets programs play solitaire: built-in Go players and external
+// engines speaking a line-based protocol over stdin and stdout.
+package bot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// ErrResign is returned by a Player that gives up on the current deal.
+var ErrResign = errors.New("resigned")
+
+// Player chooses moves. Choose is given the game and its legal moves (never
+// empty) and returns one of them. It must not modify g; look-ahead should work
+// on g.Clone().
+type Player interface {
+	Choose(g *game.Game, legal []game.Move) (game.Move, error)
+}
+
+// Finisher is implemented by players that want to hear how each game ended.
+type Finisher interface {
+	Finish(g *game.Game, r Result) error
+}
+
+// Result is the outcome of one game.
+type Result struct {
+	Seed     int64
+	Won      bool
+	Resigned bool
+	Moves    int // Moves played, including draws and recycles
+}
+
+// Play lets p play g until it is won, no legal move remains, p resigns or
+// maxMoves moves have been played. An error means the player failed, not that
+// it lost.
+func Play(p Player, g *game.Game, maxMoves int) (Result, error) {
+	r := Result{Seed: g.Seed}
+	for !g.IsWon && len(g.History) < maxMoves {
+		legal := g.LegalMoves()
+		if len(legal) == 0 {
+			g.Resign() // Stuck
+			break
+		}
+		m, err := p.Choose(g, legal)
+		if errors.Is(err, ErrResign) {
+			r.Resigned = true
+			g.Resign()
+			break
+		}
+		if err != nil {
+			return r, err
+		}
+		if err := g.Apply(m); err != nil {
+			return r, fmt.Errorf("illegal move %s: %w", m, err)
+		}
+	}
+
+	r.Won = g.IsWon
+	r.Moves = len(g.History)
+	if f, ok := p.(Finisher); ok {
+		if err := f.Finish(g, r); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// Summary accumulates results over many games.
+type Summary struct {
+	Games    int
+	Won      int
+	Moves    int // Total moves over all games
+	MovesSq  int // Total of the squared move counts, for the spread
+	WonMoves int // Total moves over won games
+}
+
+// Add counts one result.
+func (s *Summary) Add(r Result) {
+	s.Games++
+	s.Moves += r.Moves
+	s.MovesSq += r.Moves * r.Moves
+	if r.Won {
+		s.Won++
+		s.WonMoves += r.Moves
+	}
+}
+
+// WinRate returns the fraction of games won, from 0 to 1.
+func (s Summary) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Won) / float64(s.Games)
+}
+
+// AverageMoves returns the mean number of moves per game.
+func (s Summary) AverageMoves() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Moves) / float64(s.Games)
+}
+
+// AverageWinMoves returns the mean number of moves per won game.
+func (s Summary) AverageWinMoves() float64 {
+	if s.Won == 0 {
+		return 0
+	}
+	return float64(s.WonMoves) / float64(s.Won)
+}
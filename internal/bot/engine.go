@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// Engine is an external bot process spoken to over the engine protocol. It
+// plays one game at a time.
+type Engine struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	w       *bufio.Writer
+	lines   chan string
+	timeout time.Duration
+	current *game.Game // Game the engine was last told about
+}
+
+// StartEngine runs the command and performs the protocol handshake. Every
+// reply must arrive within timeout.
+func StartEngine(timeout time.Duration, name string, args ...string) (*Engine, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	e := &Engine{
+		cmd:     cmd,
+		stdin:   stdin,
+		w:       bufio.NewWriter(stdin),
+		lines:   make(chan string),
+		timeout: timeout,
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			e.lines <- scanner.Text()
+		}
+		close(e.lines)
+	}()
+
+	if err := e.send(fmt.Sprintf("solitaire %d", ProtocolVersion)); err != nil {
+		e.Close()
+		return nil, err
+	}
+	reply, err := e.read()
+	if err == nil && reply != "ready" {
+		err = fmt.Errorf("engine: expected \"ready\", got %q", reply)
+	}
+	if err != nil {
+		e.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// Choose sends the position and legal moves and waits for the engine's move.
+func (e *Engine) Choose(g *game.Game, legal []game.Move) (game.Move, error) {
+	if e.current != g {
+		e.current = g
+		if err := e.send(fmt.Sprintf("newgame %d %s", g.Seed, g.Rules)); err != nil {
+			return game.Move{}, err
+		}
+	}
+
+	tokens := make([]string, len(legal))
+	for i, m := range legal {
+		tokens[i] = MoveToken(m)
+	}
+	if err := e.send("position " + Position(g) + "\nmoves " + strings.Join(tokens, " ") + "\ngo"); err != nil {
+		return game.Move{}, err
+	}
+
+	reply, err := e.read()
+	if err != nil {
+		return game.Move{}, err
+	}
+	if reply == "resign" {
+		return game.Move{}, ErrResign
+	}
+	token, ok := strings.CutPrefix(reply, "move ")
+	if !ok {
+		return game.Move{}, fmt.Errorf("engine: expected a move, got %q", reply)
+	}
+	m, err := g.ParseMove(strings.TrimSpace(token))
+	if err != nil {
+		return game.Move{}, fmt.Errorf("engine: %w", err)
+	}
+	for _, l := range legal {
+		if l == m {
+			return m, nil
+		}
+	}
+	return game.Move{}, fmt.Errorf("engine: %s is not a legal move", token)
+}
+
+// Finish tells the engine how the game ended.
+func (e *Engine) Finish(g *game.Game, r Result) error {
+	outcome := "lost"
+	if r.Won {
+		outcome = "won"
+	}
+	return e.send(fmt.Sprintf("result %s %d", outcome, r.Moves))
+}
+
+// Close asks the engine to quit and waits for it, killing it if it does not
+// exit within the reply timeout.
+func (e *Engine) Close() error {
+	e.send("quit")
+	e.stdin.Close()
+
+	// Drain stdout before Wait, which must not run while the pipe is being read.
+	timeout := time.After(e.timeout)
+	for open := true; open; {
+		select {
+		case _, open = <-e.lines:
+		case <-timeout:
+			e.cmd.Process.Kill()
+			timeout = nil
+		}
+	}
+	return e.cmd.Wait()
+}
+
+func (e *Engine) send(lines string) error {
+	e.w.WriteString(lines + "\n")
+	if err := e.w.Flush(); err != nil {
+		return fmt.Errorf("engine: %w", err)
+	}
+	return nil
+}
+
+// read returns the engine's next line that is not an info line.
+func (e *Engine) read() (string, error) {
+	deadline := time.After(e.timeout)
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				return "", fmt.Errorf("engine exited")
+			}
+			line = strings.TrimSpace(line)
+			if line == "" || line == "info" || strings.HasPrefix(line, "info ") {
+				continue
+			}
+			return line, nil
+		case <-deadline:
+			return "", fmt.Errorf("engine did not reply within %s", e.timeout)
+		}
+	}
+}
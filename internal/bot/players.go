@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// Random plays a uniformly random legal move. Its choices in each game are
+// determined by Seed and the deal's seed.
+type Random struct {
+	Seed int64
+
+	rng  *rand.Rand
+	game *game.Game
+}
+
+func (p *Random) Choose(g *game.Game, legal []game.Move) (game.Move, error) {
+	if p.game != g {
+		p.game = g
+		p.rng = rand.New(rand.NewSource(p.Seed ^ g.Seed))
+	}
+	if stalled(g) {
+		return game.Move{}, ErrResign
+	}
+	return legal[p.rng.Intn(len(legal))], nil
+}
+
+// Greedy plays the move with the best immediate payoff: a card to a
+// foundation, then a move that turns up a face-down card, then a waste card
+// to the tableau, then a draw. Ties go to the first legal move.
+type Greedy struct{}
+
+func (Greedy) Choose(g *game.Game, legal []game.Move) (game.Move, error) {
+	if stalled(g) {
+		return game.Move{}, ErrResign
+	}
+	best, bestScore := legal[0], -1
+	for _, m := range legal {
+		score := -1
+		switch {
+		case m.Kind != game.MoveTransfer:
+			score = 1
+		case isFoundation(m.To) && !isFoundation(m.From):
+			score = 4
+		case m.From >= game.TableauPile1 && m.Index > 0 && !g.GetPile(m.From).Cards[m.Index-1].FaceUp:
+			score = 3
+		case m.From == game.WastePile || m.From == game.StockPile:
+			score = 2
+		}
+		if score > bestScore {
+			best, bestScore = m, score
+		}
+	}
+	if bestScore < 0 {
+		return game.Move{}, ErrResign
+	}
+	return best, nil
+}
+
+// Heuristic plays by the solver's move ordering, which above all prefers
+// moves that turn up face-down cards, without looking ahead. It never takes
+// cards back off the foundations.
+type Heuristic struct{}
+
+func (Heuristic) Choose(g *game.Game, legal []game.Move) (game.Move, error) {
+	if stalled(g) {
+		return game.Move{}, ErrResign
+	}
+	moves := solver.Candidates(g)
+	// Skip moves that would only undo the previous one
+	for _, m := range moves {
+		if !isFoundation(m.From) && !reverses(g, m) {
+			return m, nil
+		}
+	}
+	return game.Move{}, ErrResign
+}
+
+// Solver plays the solver's line, re-solving from the current position with
+// a budget of MaxNodes whenever it has none, at most once every solverRetry
+// moves. It sees face-down cards, so it shows what perfect play could win
+// rather than what a player could. Until the solver finds a line it plays as
+// Heuristic, and it resigns once the solver finds no win.
+type Solver struct {
+	MaxNodes int
+
+	plan    []game.Move
+	game    *game.Game
+	retryAt int // Length of the history at which to solve again
+}
+
+// solverRetry is how many moves Solver plays as Heuristic after the solver
+// ran out of budget before it tries again.
+const solverRetry = 10
+
+func (p *Solver) Choose(g *game.Game, legal []game.Move) (game.Move, error) {
+	if p.game != g {
+		p.game, p.plan, p.retryAt = g, nil, 0
+	}
+	if len(p.plan) == 0 && len(g.History) >= p.retryAt {
+		r := solver.Solve(context.Background(), g, solver.Options{MaxNodes: p.MaxNodes})
+		if r.Status == solver.NoWinFound {
+			return game.Move{}, ErrResign
+		}
+		p.plan = r.Moves
+		p.retryAt = len(g.History) + solverRetry
+	}
+	if len(p.plan) > 0 {
+		m := p.plan[0]
+		p.plan = p.plan[1:]
+		return m, nil
+	}
+	return Heuristic{}.Choose(g, legal)
+}
+
+// stalled reports whether the stock has gone round twice without a card
+// being played, so drawing on can only repeat the same positions.
+func stalled(g *game.Game) bool {
+	idle := 0
+	for i := len(g.History) - 1; i >= 0 && g.History[i].Kind != game.MoveTransfer; i-- {
+		idle++
+	}
+	return idle > 2*(len(g.Stock.Cards)+len(g.Waste.Cards)+1)
+}
+
+// reverses reports whether m sends cards straight back along the last move.
+func reverses(g *game.Game, m game.Move) bool {
+	if len(g.History) == 0 || m.Kind != game.MoveTransfer {
+		return false
+	}
+	last := g.History[len(g.History)-1]
+	return last.Kind == game.MoveTransfer && last.To == m.From && last.From == m.To
+}
+
+func isFoundation(i int) bool {
+	return i >= game.FoundationPile1 && i <= game.FoundationPile4
+}
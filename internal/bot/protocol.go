@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// The engine protocol is line based and plain ASCII. The host writes to the
+// engine's stdin and reads its stdout; the engine may write diagnostics to
+// stderr, which is passed through.
+//
+//	host:   solitaire 1                        protocol version; the engine replies "ready"
+//	host:   newgame 42 draw=1 redeals=unlimited empty=kings ...
+//	                                           a new deal: seed, then the house rules
+//	host:   position S:23 W:7D F1:- F2:- F3:- F4:- T1:7D T2:#,4D ...
+//	host:   moves D W->T3 T2:1->T3
+//	host:   go
+//	engine: move W->T3                         one of the listed moves, or "resign"
+//	host:   result won 154                     or "result lost 2000"
+//	host:   quit
+//
+// A position lists every pile in order. The stock gives only its card count.
+// Other piles list their cards bottom first, separated by commas, with "#" for
+// a face-down card and "-" for an empty pile. A card is its rank (A, 2-9, T,
+// J, Q, K) followed by its suit (S, H, D, C). Moves use the game notation with
+// "->" for the arrow. Engines may send "info ..." lines at any time; they are
+// ignored.
+const ProtocolVersion = 1
+
+const ranks = "A23456789TJQK"
+const suits = "SHDC" // In game.Suit order
+
+// CardToken returns the protocol form of a face-up card, e.g. "TH".
+func CardToken(c game.Card) string {
+	return string(ranks[c.Rank-1]) + string(suits[c.Suit])
+}
+
+// Position returns the position line for g, without the "position" keyword.
+func Position(g *game.Game) string {
+	var b strings.Builder
+	b.WriteString("S:" + strconv.Itoa(len(g.Stock.Cards)))
+	for i := game.WastePile; i <= game.TableauPile7; i++ {
+		b.WriteString(" " + game.PileName(i) + ":")
+		cards := g.GetPile(i).Cards
+		if len(cards) == 0 {
+			b.WriteString("-")
+			continue
+		}
+		for j, c := range cards {
+			if j > 0 {
+				b.WriteString(",")
+			}
+			if c.FaceUp {
+				b.WriteString(CardToken(*c))
+			} else {
+				b.WriteString("#")
+			}
+		}
+	}
+	return b.String()
+}
+
+// MoveToken returns the protocol form of a move, e.g. "T5:2->F1".
+func MoveToken(m game.Move) string {
+	return strings.Replace(m.String(), "→", "->", 1)
+}
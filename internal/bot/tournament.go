@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// Entrant is a player in a tournament. New is called once per worker, so
+// players need not be safe for concurrent use.
+type Entrant struct {
+	Name string
+	New  func() Player
+}
+
+// BuiltInNames lists the players BuiltIn knows, weakest first.
+var BuiltInNames = []string{"random", "greedy", "heuristic", "solver"}
+
+// BuiltIn returns the named built-in player. maxNodes is the search budget of
+// the solver player.
+func BuiltIn(name string, maxNodes int) (Entrant, error) {
+	var New func() Player
+	switch name {
+	case "random":
+		New = func() Player { return &Random{} }
+	case "greedy":
+		New = func() Player { return Greedy{} }
+	case "heuristic":
+		New = func() Player { return Heuristic{} }
+	case "solver":
+		New = func() Player { return &Solver{MaxNodes: maxNodes} }
+	default:
+		return Entrant{}, fmt.Errorf("unknown player %q (want one of %s)", name, strings.Join(BuiltInNames, ", "))
+	}
+	return Entrant{Name: name, New: New}, nil
+}
+
+// TournamentOptions describe the deals every entrant plays.
+type TournamentOptions struct {
+	Seed     int64 // Seed of the first deal; the others follow it
+	Games    int
+	Rules    game.Rules
+	MaxMoves int
+	Workers  int
+}
+
+// Standing is an entrant's record over a tournament.
+type Standing struct {
+	Name string
+	Summary
+}
+
+// RunTournament plays every entrant on the same seeded deals across a pool of
+// workers and returns their standings in entrant order. Results do not depend
+// on the number of workers.
+func RunTournament(ctx context.Context, entrants []Entrant, opts TournamentOptions) ([]Standing, error) {
+	type job struct {
+		entrant int
+		seed    int64
+	}
+	jobs := make(chan job)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	standings := make([]Standing, len(entrants))
+	for i, e := range entrants {
+		standings[i].Name = e.Name
+	}
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < max(opts.Workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			players := make([]Player, len(entrants))
+			for j := range jobs {
+				if players[j.entrant] == nil {
+					players[j.entrant] = entrants[j.entrant].New()
+				}
+				r, err := Play(players[j.entrant], game.NewGameWithRules(j.seed, opts.Rules), opts.MaxMoves)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("%s, seed %d: %w", entrants[j.entrant].Name, j.seed, err)
+					cancel()
+				}
+				standings[j.entrant].Add(r)
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < opts.Games; i++ {
+		for e := range entrants {
+			select {
+			case jobs <- job{entrant: e, seed: opts.Seed + int64(i)}:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return standings, firstErr
+	}
+	return standings, ctx.Err()
+}
+
+// WinRateInterval returns the 95% Wilson score interval for the win rate.
+func (s Summary) WinRateInterval() (lo, hi float64) {
+	if s.Games == 0 {
+		return 0, 1
+	}
+	const z = 1.96
+	n := float64(s.Games)
+	p := s.WinRate()
+	centre := (p + z*z/(2*n)) / (1 + z*z/n)
+	half := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+	return max(centre-half, 0), min(centre+half, 1)
+}
+
+// MovesMargin returns the half-width of the 95% confidence interval for the
+// mean number of moves per game.
+func (s Summary) MovesMargin() float64 {
+	if s.Games < 2 {
+		return 0
+	}
+	n := float64(s.Games)
+	mean := s.AverageMoves()
+	variance := (float64(s.MovesSq) - n*mean*mean) / (n - 1)
+	return 1.96 * math.Sqrt(max(variance, 0)/n)
+}
+
+// WriteStandings prints standings as an aligned comparison table.
+func WriteStandings(w io.Writer, standings []Standing) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PLAYER\tGAMES\tWON\tWIN %\t95% CI\tMEAN MOVES\t95% CI")
+	for _, s := range standings {
+		lo, hi := s.WinRateInterval()
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%.1f-%.1f\t%.1f\t±%.1f\n",
+			s.Name, s.Games, s.Won, 100*s.WinRate(), 100*lo, 100*hi, s.AverageMoves(), s.MovesMargin())
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,178 @@
+// Package config loads and saves the player's settings.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/paths"
+)
+
+// FileName is the name of the settings file inside the config directory.
+const FileName = "config.json"
+
+// Scoring modes
+const (
+	ScoringStandard = "standard"
+	ScoringVegas    = "vegas"
+	ScoringNone     = "none"
+)
+
+// Themes
+const (
+	ThemeFelt     = "felt"
+	ThemeMidnight = "midnight"
+	ThemeContrast = "contrast"
+)
+
+// Card sizes
+const (
+	CardsNormal  = "normal"
+	CardsCompact = "compact"
+)
+
+// Keymaps
+const (
+	KeymapVim   = "vim"
+	KeymapEmacs = "emacs"
+)
+
+// The values each choice may take, in the order the settings screen offers them.
+var (
+	Scorings  = []string{ScoringStandard, ScoringVegas, ScoringNone}
+	Themes    = []string{ThemeFelt, ThemeMidnight, ThemeContrast}
+	CardSizes = []string{CardsNormal, CardsCompact}
+	Keymaps   = []string{KeymapVim, KeymapEmacs}
+	Redeals   = []string{"unlimited", "0", "1", "2", "3"}
+)
+
+// Config holds the player's settings.
+type Config struct {
+	Draw       int    `json:"draw"`    // Cards turned per draw, 1 or 3
+	Redeals    string `json:"redeals"` // A number, or "unlimited"
+	Scoring    string `json:"scoring"`
+	AutoPlay   bool   `json:"auto_play"` // Play safe cards to the foundations
+	Theme      string `json:"theme"`
+	CardSize   string `json:"card_size"`
+	Keymap     string `json:"keymap"`
+	Animations bool   `json:"animations"`
+	Practice   bool   `json:"practice"` // Deal open games
+	Analysis   bool   `json:"analysis"` // Analyse the position in the background
+
+	path string
+}
+
+// Default returns the settings used when there is no file. They are kept in
+// memory only.
+func Default() *Config {
+	return &Config{
+		Draw:       1,
+		Redeals:    "unlimited",
+		Scoring:    ScoringStandard,
+		Theme:      ThemeFelt,
+		CardSize:   CardsNormal,
+		Keymap:     KeymapVim,
+		Animations: true,
+		Analysis:   true,
+	}
+}
+
+// DefaultPath returns the location of the settings file in the config directory.
+func DefaultPath() (string, error) {
+	return paths.ConfigFile(FileName)
+}
+
+// Load reads the settings from path. A missing file yields the defaults and
+// settings the file leaves out keep their default. A file that cannot be
+// read, or holds an unknown or invalid setting, yields an error so it is
+// never overwritten.
+func Load(path string) (*Config, error) {
+	c := Default()
+	c.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(c); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the settings back to the file they were loaded from. Default
+// settings have no file and are not saved.
+func (c *Config) Save() error {
+	if c.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so a crash never leaves a truncated file.
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// Validate reports the first setting that holds a value it cannot take.
+func (c *Config) Validate() error {
+	if _, err := c.rules(); err != nil {
+		return err
+	}
+	for _, s := range []struct {
+		name, value string
+		valid       []string
+	}{
+		{"scoring", c.Scoring, Scorings},
+		{"theme", c.Theme, Themes},
+		{"card_size", c.CardSize, CardSizes},
+		{"keymap", c.Keymap, Keymaps},
+	} {
+		if !slices.Contains(s.valid, s.value) {
+			return fmt.Errorf("%s %q: must be %s", s.name, s.value, oneOf(s.valid))
+		}
+	}
+	return nil
+}
+
+// Rules returns the default rules with the draw count and redeals of a valid
+// configuration.
+func (c *Config) Rules() game.Rules {
+	r, _ := c.rules()
+	return r
+}
+
+func (c *Config) rules() (game.Rules, error) {
+	return game.ParseRules(fmt.Sprintf("draw=%d redeals=%s", c.Draw, c.Redeals))
+}
+
+// oneOf lists values for an error message: "a, b or c".
+func oneOf(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
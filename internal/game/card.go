@@ -79,3 +79,8 @@ type Card struct {
 	Rank   Rank
 	FaceUp bool
 }
+
+// String returns the card's rank and suit, e.g. "10♥".
+func (c Card) String() string {
+	return c.Rank.String() + c.Suit.String()
+}
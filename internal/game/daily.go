@@ -0,0 +1,11 @@
+package game
+
+import "time"
+
+// DailySeed returns the seed of the daily challenge for the UTC date of t.
+// The seed is the date written as YYYYMMDD, so everyone gets the same deal
+// on the same day.
+func DailySeed(t time.Time) int64 {
+	y, m, d := t.UTC().Date()
+	return int64(y*10000 + int(m)*100 + d)
+}
@@ -12,3 +12,23 @@ func NewDeck() []*Card {
 	}
 	return deck
 }
+
+// Shuffle shuffles deck in place, deterministically from seed. It uses its own
+// generator rather than math/rand so that a seed deals the same cards on every
+// platform and Go version.
+func Shuffle(deck []*Card, seed int64) {
+	state := uint64(seed)
+	for i := len(deck) - 1; i > 0; i-- {
+		j := int(splitmix64(&state) % uint64(i+1))
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+}
+
+// splitmix64 advances state and returns the next pseudo-random value.
+func splitmix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,26 @@
+package game
+
+import "errors"
+
+// Move errors returned by TryMove, CheckMove, Apply and Peek. Match them with errors.Is.
+var (
+	ErrInvalidPile          = errors.New("invalid pile")
+	ErrInvalidCard          = errors.New("no card at that position")
+	ErrFaceDown             = errors.New("face-down card in stack")
+	ErrNotTopCard           = errors.New("only the top card can move from that pile")
+	ErrKingOnly             = errors.New("only Kings can go on an empty column")
+	ErrWrongColor           = errors.New("card must be the opposite colour")
+	ErrWrongRank            = errors.New("card must be one rank lower")
+	ErrFoundationSuit       = errors.New("wrong suit for foundation")
+	ErrFoundationRank       = errors.New("foundations build up from Ace one rank at a time")
+	ErrMultipleToFoundation = errors.New("only one card at a time can go to a foundation")
+	ErrIllegalMove          = errors.New("cards cannot move between those piles")
+	ErrFoundationLocked     = errors.New("cards cannot come back off the foundations")
+	ErrStockLocked          = errors.New("stock cards must be drawn before they are played")
+	ErrPartialStack         = errors.New("only whole face-up runs can move")
+	ErrStockEmpty           = errors.New("stock is empty")
+	ErrStockNotEmpty        = errors.New("stock must be empty to recycle the waste")
+	ErrWasteEmpty           = errors.New("waste is empty")
+	ErrNoRedeals            = errors.New("no redeals left")
+	ErrNotHidden            = errors.New("that card is not hidden")
+)
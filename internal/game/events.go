@@ -0,0 +1,100 @@
+package game
+
+// Event is something that happened on the board. Observers receive one of the
+// concrete event types below and switch on it.
+type Event interface {
+	event()
+}
+
+// CardMoved is emitted when cards move between piles through Move or Apply.
+type CardMoved struct {
+	Move  Move
+	Cards []Card // The moved cards, bottom first
+}
+
+// CardFlipped is emitted when a face-down tableau card is turned up.
+type CardFlipped struct {
+	Pile int
+	Card Card
+}
+
+// StockDrawn is emitted when cards are drawn from the stock to the waste.
+type StockDrawn struct {
+	Cards []Card
+}
+
+// WasteRecycled is emitted when the waste is turned back over into the stock.
+type WasteRecycled struct {
+	Count int // Number of cards returned to the stock
+}
+
+// FoundationCompleted is emitted when a foundation receives its King.
+type FoundationCompleted struct {
+	Pile int
+	Suit Suit
+}
+
+// CardPeeked is emitted when Peek reveals a hidden card.
+type CardPeeked struct {
+	Pile  int
+	Index int
+	Card  Card
+}
+
+// GameWon is emitted once, when the last card reaches the foundations.
+type GameWon struct{}
+
+// MoveUndone is emitted when Undo takes back a move.
+type MoveUndone struct {
+	Move Move
+}
+
+func (CardMoved) event()           {}
+func (CardFlipped) event()         {}
+func (StockDrawn) event()          {}
+func (WasteRecycled) event()       {}
+func (FoundationCompleted) event() {}
+func (CardPeeked) event()          {}
+func (GameWon) event()             {}
+func (MoveUndone) event()          {}
+
+// Observer is called synchronously, after the board has changed, for every event.
+type Observer func(Event)
+
+type subscription struct {
+	id int
+	fn Observer
+}
+
+// Subscribe registers an observer for the game's events and returns a function
+// that removes it. Observers are called in the order they subscribed. Clones do
+// not inherit observers.
+func (g *Game) Subscribe(o Observer) (unsubscribe func()) {
+	g.nextObserverID++
+	id := g.nextObserverID
+	g.observers = append(g.observers, subscription{id: id, fn: o})
+	return func() {
+		for i, s := range g.observers {
+			if s.id == id {
+				g.observers = append(g.observers[:i:i], g.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emit delivers an event to every observer.
+func (g *Game) emit(e Event) {
+	for _, s := range g.observers {
+		s.fn(e)
+	}
+}
+
+// cardValues copies the cards a pile slice points to.
+func cardValues(cards []*Card) []Card {
+	out := make([]Card, len(cards))
+	for i, c := range cards {
+		out[i] = *c
+	}
+	return out
+}
@@ -32,18 +32,42 @@ type Game struct {
 	IsWon      bool
 	ActivePile int // Using an index for now; could be an enum
 	ActiveCard int // Index of the card in the active pile
+
+	Seed     int64  // Seed the deal was shuffled from
+	Rules    Rules  // House rules the game is played under
+	Recycles int    // Times the waste has been turned back into the stock
+	History  []Move // Every move applied since the deal, in order
+	Open     bool   // Every card can be seen, face-down ones included
+	Score    int    // Points under standard scoring, peek penalties included
+	Peeks    int    // Hidden cards looked at with Peek
+	Lost     bool   // The game ended without a win; see Resign
+
+	undo           []snapshot // Board before each move in History
+	seen           uint64     // Hidden cards the player has looked at; see Seen
+	observers      []subscription
+	nextObserverID int
 }
 
-// NewGame creates a new game of Solitaire.
+// NewGame creates a new game of Solitaire from a random seed.
 func NewGame() *Game {
+	return NewGameFromSeed(rand.Int63())
+}
+
+// NewGameFromSeed creates a new game of Solitaire whose deal is fully determined by seed.
+func NewGameFromSeed(seed int64) *Game {
+	return NewGameWithRules(seed, DefaultRules())
+}
+
+// NewGameWithRules creates a new game dealt from seed and played under rules.
+func NewGameWithRules(seed int64, rules Rules) *Game {
 	// Create and shuffle a standard 52-card deck.
 	deck := NewDeck()
-	rand.Shuffle(len(deck), func(i, j int) {
-		deck[i], deck[j] = deck[j], deck[i]
-	})
+	Shuffle(deck, seed)
 
 	g := &Game{
 		IsWon:      false,
+		Seed:       seed,
+		Rules:      rules,
 		ActivePile: -1, // No pile selected initially
 		ActiveCard: -1, // No card selected initially
 	}
@@ -69,11 +93,28 @@ func NewGame() *Game {
 	return g
 }
 
+// NewOpenGame creates an open ("thoughtful") game dealt from seed and played
+// under rules. The whole deal is visible: cards keep their face-down state, so
+// moves follow the usual rules, but nothing about them is hidden.
+func NewOpenGame(seed int64, rules Rules) *Game {
+	g := NewGameWithRules(seed, rules)
+	g.Open = true
+	return g
+}
+
 // RecycleWaste moves all cards from the waste pile back to the stock pile.
 func (g *Game) RecycleWaste() {
 	if len(g.Stock.Cards) > 0 {
 		return // Can only recycle when stock is empty
 	}
+	if !g.CanRecycle() {
+		return // Out of redeals
+	}
+	count := len(g.Waste.Cards)
+	if count == 0 {
+		return // Nothing to recycle
+	}
+	g.saveUndo()
 	// Reverse the waste pile to put it back into the stock
 	for i := len(g.Waste.Cards) - 1; i >= 0; i-- {
 		card := g.Waste.Cards[i]
@@ -81,16 +122,31 @@ func (g *Game) RecycleWaste() {
 		g.Stock.Push(card)
 	}
 	g.Waste.Cards = nil // Empty the waste pile
+	g.Recycles++
+	g.Score += g.Rules.recyclePoints()
+	g.History = append(g.History, Move{Kind: MoveRecycle})
+	g.emit(WasteRecycled{Count: count})
+}
+
+// CanRecycle reports whether the rules allow the waste to be recycled again.
+func (g *Game) CanRecycle() bool {
+	return g.Rules.Redeals == UnlimitedRedeals || g.Recycles < g.Rules.Redeals
 }
 
-// DrawCard moves a card from the stock to the waste pile.
+// DrawCard moves Rules.DrawCount cards (or as many as remain) from the stock to the waste pile.
 func (g *Game) DrawCard() {
 	if len(g.Stock.Cards) == 0 {
 		return // Or handle recycling waste here, will be added later
 	}
-	card := g.Stock.Pop()
-	card.FaceUp = true
-	g.Waste.Push(card)
+	g.saveUndo()
+	n := min(max(g.Rules.DrawCount, 1), len(g.Stock.Cards))
+	for i := 0; i < n; i++ {
+		card := g.Stock.Pop()
+		card.FaceUp = true
+		g.Waste.Push(card)
+	}
+	g.History = append(g.History, Move{Kind: MoveDraw})
+	g.emit(StockDrawn{Cards: cardValues(g.Waste.Cards[len(g.Waste.Cards)-n:])})
 }
 
 // CheckWinCondition verifies if the game has been won and updates the game state.
@@ -153,114 +209,160 @@ func (g *Game) ClearSelection() {
 }
 
 // Move attempts to move a card (or stack of cards) from a source to a destination pile.
-// Returns true if the move was successful, false otherwise.
+// Returns true if the move was successful, false otherwise. Successful moves are
+// appended to the game's History. Use TryMove to learn why a move was rejected.
 func (g *Game) Move(sourcePileIndex, sourceCardIndex, destPileIndex int) bool {
+	return g.TryMove(sourcePileIndex, sourceCardIndex, destPileIndex) == nil
+}
+
+// TryMove is like Move but returns the reason a move was rejected, one of the
+// Err* move errors, or nil if the move was made.
+func (g *Game) TryMove(sourcePileIndex, sourceCardIndex, destPileIndex int) error {
+	if err := g.CheckMove(sourcePileIndex, sourceCardIndex, destPileIndex); err != nil {
+		return err
+	}
+
+	g.saveUndo()
 	sourcePile := g.GetPile(sourcePileIndex)
 	destPile := g.GetPile(destPileIndex)
+	moved := len(sourcePile.Cards) - sourceCardIndex
+	destPile.Cards = append(destPile.Cards, sourcePile.Cards[sourceCardIndex:]...)
+	sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
+
+	// A card played straight from the stock is turned up on the way.
+	destPile.Peek().FaceUp = true
 
-	if sourcePile == nil || destPile == nil {
-		return false // Invalid pile indices
+	// Flip the new top card of the source tableau if it's face down
+	flipped := isTableau(sourcePileIndex) && len(sourcePile.Cards) > 0 && !sourcePile.Peek().FaceUp
+	if flipped {
+		sourcePile.Peek().FaceUp = true
+	}
+
+	move := Move{Kind: MoveTransfer, From: sourcePileIndex, Index: sourceCardIndex, To: destPileIndex}
+	g.History = append(g.History, move)
+	g.Score += transferPoints(sourcePileIndex, destPileIndex, flipped)
+
+	g.emit(CardMoved{Move: move, Cards: cardValues(destPile.Cards[len(destPile.Cards)-moved:])})
+	if flipped {
+		g.emit(CardFlipped{Pile: sourcePileIndex, Card: *sourcePile.Peek()})
+	}
+	if isFoundation(destPileIndex) && len(destPile.Cards) == 13 {
+		g.emit(FoundationCompleted{Pile: destPileIndex, Suit: destPile.Peek().Suit})
+	}
+	if !g.IsWon && g.HasWon() {
+		g.IsWon = true
+		g.emit(GameWon{})
+	}
+	return nil
+}
+
+// CheckMove reports why moving the cards from sourceCardIndex up in the source
+// pile onto the destination pile would be rejected, or nil if the move is legal.
+// The board is not changed.
+func (g *Game) CheckMove(sourcePileIndex, sourceCardIndex, destPileIndex int) error {
+	sourcePile := g.GetPile(sourcePileIndex)
+	destPile := g.GetPile(destPileIndex)
+
+	if sourcePile == nil || destPile == nil || sourcePileIndex == destPileIndex {
+		return ErrInvalidPile
 	}
 	if sourceCardIndex < 0 || sourceCardIndex >= len(sourcePile.Cards) {
-		return false // Invalid source card index
+		return ErrInvalidCard
 	}
 
 	cardsToMove := sourcePile.Cards[sourceCardIndex:]
-	if len(cardsToMove) == 0 {
-		return false // No cards to move
+
+	// Only tableaus hold stacks; elsewhere just the top card can move.
+	if !isTableau(sourcePileIndex) && len(cardsToMove) > 1 {
+		return ErrNotTopCard
 	}
 
-	// Rule: Cards moved from waste or tableau must be face up.
-	for _, card := range cardsToMove {
-		if !card.FaceUp {
-			return false // Cannot move face-down cards
+	switch {
+	case sourcePileIndex == StockPile:
+		if !g.Rules.StockToTableau {
+			return ErrStockLocked
+		}
+		if !isTableau(destPileIndex) {
+			return ErrIllegalMove // The rule reaches the tableau only
+		}
+	case isFoundation(sourcePileIndex):
+		if !g.Rules.FoundationToTableau {
+			return ErrFoundationLocked
+		}
+	default:
+		// Rule: Cards moved from waste or tableau must be face up.
+		for _, card := range cardsToMove {
+			if !card.FaceUp {
+				return ErrFaceDown
+			}
 		}
 	}
 
-	// Determine move type based on source and destination pile indices
+	// Without partial stack moves a run leaves a column whole, unless its top card
+	// is going up to a foundation.
+	if isTableau(sourcePileIndex) && !g.Rules.PartialStacks && !isFoundation(destPileIndex) &&
+		sourceCardIndex > 0 && sourcePile.Cards[sourceCardIndex-1].FaceUp {
+		return ErrPartialStack
+	}
+
 	// Indices: 0:Stock, 1:Waste, 2-5:Foundations, 6-12:Tableaus
+	moving := cardsToMove[0]
 	switch {
-	case sourcePileIndex >= TableauPile1 && sourcePileIndex <= TableauPile7 && destPileIndex >= TableauPile1 && destPileIndex <= TableauPile7:
-		// Tableau to Tableau
-		if len(destPile.Cards) == 0 && cardsToMove[0].Rank == King {
-			// Valid move: King to empty tableau
-			destPile.Cards = append(destPile.Cards, cardsToMove...)
-			sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
-			// Flip the new top card of the source tableau if it's face down
-			if len(sourcePile.Cards) > 0 && !sourcePile.Peek().FaceUp {
-				sourcePile.Peek().FaceUp = true
+	case isTableau(destPileIndex):
+		if len(destPile.Cards) == 0 {
+			if moving.Rank != King && !g.Rules.AnyCardOnEmpty {
+				return ErrKingOnly
 			}
-			return true
-		} else if len(destPile.Cards) > 0 && g.isValidTableauMove(cardsToMove[0], destPile.Peek()) {
-			// Valid move: card to non-empty tableau
-			destPile.Cards = append(destPile.Cards, cardsToMove...)
-			sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
-			// Flip the new top card of the source tableau if it's face down
-			if len(sourcePile.Cards) > 0 && !sourcePile.Peek().FaceUp {
-				sourcePile.Peek().FaceUp = true
-			}
-			return true
+			return nil
+		}
+		top := destPile.Peek()
+		// Must be opposite color and one rank lower
+		if moving.Suit.Color() == top.Suit.Color() {
+			return ErrWrongColor
+		}
+		if moving.Rank != top.Rank-1 {
+			return ErrWrongRank
 		}
-	case sourcePileIndex == WastePile && destPileIndex >= FoundationPile1 && destPileIndex <= FoundationPile4:
-		// Waste to Foundation
-		if g.isValidFoundationMove(cardsToMove[0], destPile, destPileIndex-FoundationPile1) {
-			destPile.Cards = append(destPile.Cards, cardsToMove...)
-			sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
-			return true
+		return nil
+
+	case isFoundation(destPileIndex):
+		if isFoundation(sourcePileIndex) {
+			// Foundation to Foundation is not allowed in Klondike, only to build up.
+			return ErrIllegalMove
 		}
-	case sourcePileIndex == WastePile && destPileIndex >= TableauPile1 && destPileIndex <= TableauPile7:
-		// Waste to Tableau
-		if len(destPile.Cards) == 0 && cardsToMove[0].Rank == King {
-			// Only Kings can be placed on empty tableaus
-			destPile.Cards = append(destPile.Cards, cardsToMove...)
-			sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
-			return true
-		} else if len(destPile.Cards) > 0 && g.isValidTableauMove(cardsToMove[0], destPile.Peek()) {
-			destPile.Cards = append(destPile.Cards, cardsToMove...)
-			sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
-			return true
+		if len(cardsToMove) > 1 {
+			return ErrMultipleToFoundation
 		}
-	case sourcePileIndex >= TableauPile1 && sourcePileIndex <= TableauPile7 && destPileIndex >= FoundationPile1 && destPileIndex <= FoundationPile4:
-		// Tableau to Foundation
-		if len(cardsToMove) == 1 && g.isValidFoundationMove(cardsToMove[0], destPile, destPileIndex-FoundationPile1) {
-			destPile.Cards = append(destPile.Cards, cardsToMove...)
-			sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
-			// Flip the new top card of the source tableau if it's face down
-			if len(sourcePile.Cards) > 0 && !sourcePile.Peek().FaceUp {
-				sourcePile.Peek().FaceUp = true
+		// If foundation is empty, only an Ace can be placed
+		if len(destPile.Cards) == 0 {
+			if moving.Rank != Ace {
+				return ErrFoundationRank
 			}
-			return true
+			return nil
+		}
+		// Otherwise, must be same suit and one rank higher
+		top := destPile.Peek()
+		if moving.Suit != top.Suit {
+			return ErrFoundationSuit
 		}
-	case sourcePileIndex >= FoundationPile1 && sourcePileIndex <= FoundationPile4 && destPileIndex >= TableauPile1 && destPileIndex <= TableauPile7:
-		// Foundation to Tableau
-		if len(cardsToMove) == 1 && (len(destPile.Cards) == 0 || g.isValidTableauMove(cardsToMove[0], destPile.Peek())) {
-			destPile.Cards = append(destPile.Cards, cardsToMove...)
-			sourcePile.Cards = sourcePile.Cards[:sourceCardIndex]
-			return true
+		if moving.Rank != top.Rank+1 {
+			return ErrFoundationRank
 		}
-	case sourcePileIndex >= FoundationPile1 && sourcePileIndex <= FoundationPile4 && destPileIndex >= FoundationPile1 && destPileIndex <= FoundationPile4:
-		// Foundation to Foundation is invalid directly, but cards may move from one to another if empty
-		// This is generally not allowed in Klondike, only to build up.
-		return false
+		return nil
 	}
 
-	return false
+	// Nothing can be placed on the stock or waste directly.
+	return ErrIllegalMove
 }
 
-func (g *Game) isValidTableauMove(movingCard *Card, topDestCard *Card) bool {
-	// Must be opposite color and one rank lower
-	return movingCard.Suit.Color() != topDestCard.Suit.Color() && movingCard.Rank == topDestCard.Rank-1
+// isTableau reports whether index is one of the tableau piles.
+func isTableau(index int) bool {
+	return index >= TableauPile1 && index <= TableauPile7
 }
 
-func (g *Game) isValidFoundationMove(movingCard *Card, destPile *Pile, foundationIndex int) bool {
-	// If foundation is empty, only an Ace can be placed
-	if len(destPile.Cards) == 0 {
-		return movingCard.Rank == Ace // && movingCard.Suit == g.Foundations[foundationIndex].expectedSuit (need to track expected suit)
-	}
-
-	// Otherwise, must be same suit and one rank higher
-	topDestCard := destPile.Peek()
-	return movingCard.Suit == topDestCard.Suit && movingCard.Rank == topDestCard.Rank+1
+// isFoundation reports whether index is one of the foundation piles.
+func isFoundation(index int) bool {
+	return index >= FoundationPile1 && index <= FoundationPile4
 }
 
 // IsWon checks if the game has been won.
@@ -0,0 +1,52 @@
+package game
+
+// LegalMoves returns the moves that Apply would accept on the current board:
+// a draw or recycle of the stock first, then transfers ordered by source pile,
+// card index and destination pile. Of several empty foundations or empty
+// columns, which are interchangeable, only the first is offered. The order is
+// stable, so bots that pick by position behave the same on every run.
+func (g *Game) LegalMoves() []Move {
+	var moves []Move
+	switch {
+	case len(g.Stock.Cards) > 0:
+		moves = append(moves, Move{Kind: MoveDraw})
+	case len(g.Waste.Cards) > 0 && g.CanRecycle():
+		moves = append(moves, Move{Kind: MoveRecycle})
+	}
+
+	for src := 0; src < numPiles; src++ {
+		cards := g.GetPile(src).Cards
+		n := len(cards)
+		first := n - 1
+		if isTableau(src) {
+			// Only face-up cards can move, and they sit above the face-down ones
+			first = 0
+			for first < n && !cards[first].FaceUp {
+				first++
+			}
+		}
+		for idx := max(first, 0); idx < n; idx++ {
+			dst := WastePile + 1
+			if idx < n-1 {
+				dst = TableauPile1 // A stack can only go to the tableau
+			}
+			emptyFoundation, emptyTableau := false, false
+			for ; dst < numPiles; dst++ {
+				if len(g.GetPile(dst).Cards) == 0 {
+					seen := &emptyTableau
+					if isFoundation(dst) {
+						seen = &emptyFoundation
+					}
+					if *seen {
+						continue
+					}
+					*seen = true
+				}
+				if g.CheckMove(src, idx, dst) == nil {
+					moves = append(moves, Move{Kind: MoveTransfer, From: src, Index: idx, To: dst})
+				}
+			}
+		}
+	}
+	return moves
+}
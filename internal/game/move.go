@@ -0,0 +1,52 @@
+package game
+
+// MoveKind identifies the kind of action recorded in a game's history.
+type MoveKind int
+
+const (
+	// MoveTransfer moves a card (or stack of cards) between two piles.
+	MoveTransfer MoveKind = iota
+	// MoveDraw draws a card from the stock to the waste.
+	MoveDraw
+	// MoveRecycle turns the waste back over into the stock.
+	MoveRecycle
+)
+
+// Move is a single action applied to a game through Move, DrawCard or RecycleWaste.
+// For transfers, From and To are pile indices and Index is the index of the first
+// moved card within the source pile. Draws and recycles leave the other fields zero.
+type Move struct {
+	Kind  MoveKind
+	From  int
+	Index int
+	To    int
+}
+
+// Apply performs the move on the game. Returns nil if the move was legal and
+// applied, or the move error explaining why it was rejected.
+func (g *Game) Apply(m Move) error {
+	switch m.Kind {
+	case MoveDraw:
+		if len(g.Stock.Cards) == 0 {
+			return ErrStockEmpty
+		}
+		g.DrawCard()
+		return nil
+	case MoveRecycle:
+		if len(g.Stock.Cards) > 0 {
+			return ErrStockNotEmpty
+		}
+		if len(g.Waste.Cards) == 0 {
+			return ErrWasteEmpty
+		}
+		if !g.CanRecycle() {
+			return ErrNoRedeals
+		}
+		g.RecycleWaste()
+		return nil
+	case MoveTransfer:
+		return g.TryMove(m.From, m.Index, m.To)
+	default:
+		return ErrIllegalMove
+	}
+}
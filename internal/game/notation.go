@@ -0,0 +1,334 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// VariantKlondike is the variant name written to notation headers.
+const VariantKlondike = "Klondike"
+
+// Game results as written to the notation header.
+const (
+	ResultWon        = "1-0"
+	ResultLost       = "0-1"
+	ResultInProgress = "*"
+)
+
+// movesPerLine controls how many moves Export writes before wrapping.
+const movesPerLine = 10
+
+// NotationError reports a problem in a game record, with 1-based line and column.
+type NotationError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *NotationError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// PileName returns the notation name of a pile: S, W, F1-F4 or T1-T7.
+func PileName(index int) string {
+	switch {
+	case index == StockPile:
+		return "S"
+	case index == WastePile:
+		return "W"
+	case index >= FoundationPile1 && index <= FoundationPile4:
+		return "F" + strconv.Itoa(index-FoundationPile1+1)
+	case index >= TableauPile1 && index <= TableauPile7:
+		return "T" + strconv.Itoa(index-TableauPile1+1)
+	default:
+		return "?"
+	}
+}
+
+// parsePileName is the inverse of PileName. Returns -1 for unknown names.
+func parsePileName(name string) int {
+	switch {
+	case name == "S":
+		return StockPile
+	case name == "W":
+		return WastePile
+	case len(name) == 2 && name[0] == 'F' && name[1] >= '1' && name[1] <= '4':
+		return FoundationPile1 + int(name[1]-'1')
+	case len(name) == 2 && name[0] == 'T' && name[1] >= '1' && name[1] <= '7':
+		return TableauPile1 + int(name[1]-'1')
+	default:
+		return -1
+	}
+}
+
+// String returns the move in notation form, e.g. "D", "R", "W→T3" or "T5:2→F1".
+// Tableau sources carry the index of the first moved card within the column.
+func (m Move) String() string {
+	switch m.Kind {
+	case MoveDraw:
+		return "D"
+	case MoveRecycle:
+		return "R"
+	}
+	from := PileName(m.From)
+	if isTableau(m.From) {
+		from += ":" + strconv.Itoa(m.Index)
+	}
+	return from + "→" + PileName(m.To)
+}
+
+// Result returns the game's result in notation form: won, lost once the
+// player has resigned, and in progress otherwise.
+func (g *Game) Result() string {
+	switch {
+	case g.IsWon:
+		return ResultWon
+	case g.Lost:
+		return ResultLost
+	}
+	return ResultInProgress
+}
+
+// Resign ends the game as lost. A won game stays won.
+func (g *Game) Resign() {
+	g.Lost = !g.IsWon
+}
+
+// Export writes the game's header and move history in solitaire notation:
+//
+//	[Variant "Klondike"]
+//	[Seed "42"]
+//	[Rules "draw=1 redeals=unlimited empty=kings foundation-back=yes partial=yes stock-play=no"]
+//	[Result "*"]
+//
+//	1. D 2. W→T3 3. T5:2→F1 4. R
+//
+// Open games carry an extra [Open "yes"] header after the rules, and games in
+// which hidden cards were peeked at a [Peeks "N"] header.
+func (g *Game) Export() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Variant %q]\n", VariantKlondike)
+	fmt.Fprintf(&b, "[Seed %q]\n", strconv.FormatInt(g.Seed, 10))
+	fmt.Fprintf(&b, "[Rules %q]\n", g.Rules.String())
+	if g.Open {
+		fmt.Fprintf(&b, "[Open %q]\n", "yes")
+	}
+	if g.Peeks > 0 {
+		fmt.Fprintf(&b, "[Peeks %q]\n", strconv.Itoa(g.Peeks))
+	}
+	fmt.Fprintf(&b, "[Result %q]\n", g.Result())
+	b.WriteString("\n")
+
+	for i, m := range g.History {
+		if i > 0 {
+			if i%movesPerLine == 0 {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		fmt.Fprintf(&b, "%d. %s", i+1, m)
+	}
+	if len(g.History) > 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Import reads a game in solitaire notation. The deal is recreated from the Seed
+// header and every move is replayed; the first illegal or malformed move is
+// reported as a *NotationError with its line and column.
+func Import(r io.Reader) (*Game, error) {
+	var (
+		g       *Game
+		seen    bool
+		headers = map[string]string{}
+	)
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "[") {
+			if g != nil {
+				return nil, &NotationError{Line: lineNo, Column: column(line, trimmed), Msg: "header after moves"}
+			}
+			key, value, err := parseHeader(trimmed)
+			if err != nil {
+				return nil, &NotationError{Line: lineNo, Column: column(line, trimmed), Msg: err.Error()}
+			}
+			headers[key] = value
+			seen = true
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+
+		if g == nil {
+			var err error
+			if g, err = newGameFromHeaders(headers, seen); err != nil {
+				return nil, &NotationError{Line: lineNo, Column: 1, Msg: err.Error()}
+			}
+		}
+		if err := replayLine(g, line, lineNo); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if g == nil {
+		var err error
+		if g, err = newGameFromHeaders(headers, seen); err != nil {
+			return nil, &NotationError{Line: lineNo, Column: 1, Msg: err.Error()}
+		}
+	}
+	g.CheckWinCondition()
+	if headers["Result"] == ResultLost {
+		g.Resign()
+	}
+	return g, nil
+}
+
+// parseHeader splits a `[Key "Value"]` line.
+func parseHeader(s string) (string, string, error) {
+	if !strings.HasSuffix(s, "]") {
+		return "", "", fmt.Errorf("unterminated header")
+	}
+	key, raw, ok := strings.Cut(strings.TrimSpace(s[1:len(s)-1]), " ")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("malformed header")
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(raw))
+	if err != nil {
+		return "", "", fmt.Errorf("malformed header value for %s", key)
+	}
+	return key, value, nil
+}
+
+// newGameFromHeaders deals the game described by the parsed headers.
+func newGameFromHeaders(headers map[string]string, seen bool) (*Game, error) {
+	if !seen {
+		return nil, fmt.Errorf("missing header")
+	}
+	if v, ok := headers["Variant"]; ok && v != VariantKlondike {
+		return nil, fmt.Errorf("unsupported variant %q", v)
+	}
+	rules, err := ParseRules(headers["Rules"])
+	if err != nil {
+		return nil, err
+	}
+	seedStr, ok := headers["Seed"]
+	if !ok {
+		return nil, fmt.Errorf("missing Seed header")
+	}
+	seed, err := strconv.ParseInt(seedStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed %q", seedStr)
+	}
+	g := NewGameWithRules(seed, rules)
+	g.Open = headers["Open"] == "yes"
+	if v, ok := headers["Peeks"]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid peek count %q", v)
+		}
+		g.Peeks = n
+		g.Score = PointsPeek * n
+	}
+	return g, nil
+}
+
+// replayLine applies every move token on a line of move text.
+func replayLine(g *Game, line string, lineNo int) error {
+	for _, tok := range tokenize(line) {
+		// Move numbers ("12.") are decoration only.
+		if strings.HasSuffix(tok.text, ".") {
+			if _, err := strconv.Atoi(strings.TrimSuffix(tok.text, ".")); err == nil {
+				continue
+			}
+		}
+		m, err := g.ParseMove(tok.text)
+		if err != nil {
+			return &NotationError{Line: lineNo, Column: tok.col, Msg: err.Error()}
+		}
+		if err := g.Apply(m); err != nil {
+			return &NotationError{Line: lineNo, Column: tok.col, Msg: fmt.Sprintf("illegal move %s: %v", tok.text, err)}
+		}
+	}
+	return nil
+}
+
+// ParseMove parses a single move in notation form against the current board,
+// which is needed to resolve tableau sources written without a card index.
+// "->" is accepted in place of "→".
+func (g *Game) ParseMove(s string) (Move, error) {
+	switch s {
+	case "D":
+		return Move{Kind: MoveDraw}, nil
+	case "R":
+		return Move{Kind: MoveRecycle}, nil
+	}
+
+	src, dst, ok := strings.Cut(strings.Replace(s, "->", "→", 1), "→")
+	if !ok {
+		return Move{}, fmt.Errorf("malformed move %q", s)
+	}
+	srcName, idxStr, hasIdx := strings.Cut(src, ":")
+	from := parsePileName(srcName)
+	to := parsePileName(dst)
+	if from < 0 || to < 0 {
+		return Move{}, fmt.Errorf("unknown pile in move %q", s)
+	}
+
+	index := len(g.GetPile(from).Cards) - 1
+	if hasIdx {
+		n, err := strconv.Atoi(idxStr)
+		if err != nil || n < 0 {
+			return Move{}, fmt.Errorf("invalid card index in move %q", s)
+		}
+		index = n
+	}
+	return Move{Kind: MoveTransfer, From: from, Index: index, To: to}, nil
+}
+
+type token struct {
+	text string
+	col  int
+}
+
+// tokenize splits a line on whitespace, keeping the 1-based column of each token.
+func tokenize(line string) []token {
+	var toks []token
+	start := -1
+	for i, r := range line {
+		if r == ' ' || r == '\t' {
+			if start >= 0 {
+				toks = append(toks, token{line[start:i], utf8.RuneCountInString(line[:start]) + 1})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		toks = append(toks, token{line[start:], utf8.RuneCountInString(line[:start]) + 1})
+	}
+	return toks
+}
+
+// column returns the 1-based column at which trimmed starts within line.
+func column(line, trimmed string) int {
+	return utf8.RuneCountInString(line[:strings.Index(line, trimmed)]) + 1
+}
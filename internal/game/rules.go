@@ -0,0 +1,117 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UnlimitedRedeals allows the waste to be recycled any number of times.
+const UnlimitedRedeals = -1
+
+// Rules are the Klondike house rules a game is played under.
+type Rules struct {
+	DrawCount int // Cards turned from the stock per draw (1 or 3)
+	Redeals   int // Times the waste may be recycled; UnlimitedRedeals for no limit
+
+	AnyCardOnEmpty      bool // Any card, not just a King, may fill an empty column
+	FoundationToTableau bool // Cards may be moved back off the foundations
+	PartialStacks       bool // Part of a face-up run may move, not just the whole run
+	StockToTableau      bool // The top stock card may be played to a column without drawing it first
+}
+
+// DefaultRules returns standard Klondike: draw one, unlimited redeals, Kings
+// only on empty columns, foundation cards may come back, partial stacks move.
+func DefaultRules() Rules {
+	return Rules{
+		DrawCount:           1,
+		Redeals:             UnlimitedRedeals,
+		FoundationToTableau: true,
+		PartialStacks:       true,
+	}
+}
+
+// String returns the rules as space-separated key=value pairs, the form used
+// in notation headers and accepted by ParseRules.
+func (r Rules) String() string {
+	redeals := "unlimited"
+	if r.Redeals != UnlimitedRedeals {
+		redeals = strconv.Itoa(r.Redeals)
+	}
+	empty := "kings"
+	if r.AnyCardOnEmpty {
+		empty = "any"
+	}
+	return fmt.Sprintf("draw=%d redeals=%s empty=%s foundation-back=%s partial=%s stock-play=%s",
+		r.DrawCount, redeals, empty, yesNo(r.FoundationToTableau), yesNo(r.PartialStacks), yesNo(r.StockToTableau))
+}
+
+// ParseRules parses rules written by Rules.String. Pairs may be separated by
+// spaces or commas; rules that are not mentioned keep their default value.
+func ParseRules(s string) (Rules, error) {
+	return DefaultRules().With(s)
+}
+
+// With returns r changed by the rules written in s, in the form ParseRules
+// accepts. Rules that s does not mention keep their value in r.
+func (r Rules) With(s string) (Rules, error) {
+	fields := strings.FieldsFunc(s, func(c rune) bool { return c == ' ' || c == ',' })
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return r, fmt.Errorf("rule %q: expected key=value", field)
+		}
+		var err error
+		switch key {
+		case "draw":
+			r.DrawCount, err = strconv.Atoi(value)
+			if err == nil && r.DrawCount != 1 && r.DrawCount != 3 {
+				err = fmt.Errorf("must be 1 or 3")
+			}
+		case "redeals":
+			if value == "unlimited" {
+				r.Redeals = UnlimitedRedeals
+			} else if r.Redeals, err = strconv.Atoi(value); err == nil && r.Redeals < 0 {
+				err = fmt.Errorf("must not be negative")
+			}
+		case "empty":
+			switch value {
+			case "kings":
+				r.AnyCardOnEmpty = false
+			case "any":
+				r.AnyCardOnEmpty = true
+			default:
+				err = fmt.Errorf("must be kings or any")
+			}
+		case "foundation-back":
+			r.FoundationToTableau, err = parseYesNo(value)
+		case "partial":
+			r.PartialStacks, err = parseYesNo(value)
+		case "stock-play":
+			r.StockToTableau, err = parseYesNo(value)
+		default:
+			return r, fmt.Errorf("unknown rule %q", key)
+		}
+		if err != nil {
+			return r, fmt.Errorf("rule %s=%s: %v", key, value, err)
+		}
+	}
+	return r, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func parseYesNo(s string) (bool, error) {
+	switch s {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("must be yes or no")
+}
@@ -0,0 +1,83 @@
+package game
+
+// Points awarded under standard Klondike scoring. A game may score below zero.
+const (
+	PointsWasteToTableau      = 5
+	PointsToFoundation        = 10
+	PointsTurnOver            = 5   // A face-down tableau card is turned up
+	PointsFoundationToTableau = -15 // A card comes back off a foundation
+	PointsRecycleDrawOne      = -100
+	PointsRecycleDrawThree    = -20
+	PointsPeek                = -50 // Looking at a hidden card, see Peek
+)
+
+// Vegas scoring: the deal costs VegasAnte and every card on a foundation
+// wins VegasCard back, so a won game nets 208.
+const (
+	VegasAnte = 52
+	VegasCard = 5
+)
+
+// VegasScore returns the game's score under Vegas scoring.
+func (g *Game) VegasScore() int {
+	cards := 0
+	for _, f := range g.Foundations {
+		cards += len(f.Cards)
+	}
+	return VegasCard*cards - VegasAnte
+}
+
+// transferPoints scores moving cards from one pile to another, plus turning
+// up the card they uncovered.
+func transferPoints(from, to int, flipped bool) int {
+	points := 0
+	switch {
+	case isFoundation(to):
+		points = PointsToFoundation
+	case isFoundation(from):
+		points = PointsFoundationToTableau
+	case from == WastePile || from == StockPile:
+		points = PointsWasteToTableau
+	}
+	if flipped {
+		points += PointsTurnOver
+	}
+	return points
+}
+
+// recyclePoints scores turning the waste back into the stock.
+func (r Rules) recyclePoints() int {
+	if r.DrawCount >= 3 {
+		return PointsRecycleDrawThree
+	}
+	return PointsRecycleDrawOne
+}
+
+// Peek reveals a hidden card: a face-down tableau card or the next card in
+// the stock. The card stays where it is and face down, but the look costs
+// PointsPeek and is counted in Peeks, so peeking games can be told apart.
+// Returns ErrNotHidden for cards that can already be seen, including every
+// card of an open game.
+func (g *Game) Peek(pileIndex, cardIndex int) (Card, error) {
+	pile := g.GetPile(pileIndex)
+	if pile == nil || (pileIndex != StockPile && !isTableau(pileIndex)) {
+		return Card{}, ErrNotHidden
+	}
+	if pileIndex == StockPile {
+		// Only the next card to be drawn can be looked at
+		cardIndex = len(pile.Cards) - 1
+	}
+	if cardIndex < 0 || cardIndex >= len(pile.Cards) {
+		return Card{}, ErrInvalidCard
+	}
+	c := *pile.Cards[cardIndex]
+	if c.FaceUp || g.Open {
+		return Card{}, ErrNotHidden
+	}
+
+	g.Peeks++
+	g.Score += PointsPeek
+	g.markSeen(c)
+	g.emit(CardPeeked{Pile: pileIndex, Index: cardIndex, Card: c})
+	return c, nil
+}
@@ -0,0 +1,157 @@
+package game
+
+// CardCode packs a card into a byte: the low six bits hold the card number
+// (suit*13 + rank-1, 0-51) and the top bit is set when the card is face up.
+type CardCode uint8
+
+const faceUpBit CardCode = 0x80
+
+// maxPileLen is the most cards any pile can hold: the stock starts with 24.
+// (A tableau peaks at 6 face-down cards plus a King-to-Ace run, 19 cards.)
+const maxPileLen = 24
+
+// numPiles is the number of piles on the board, indexed like GetPile.
+const numPiles = 13
+
+// CodeOf packs a card.
+func CodeOf(c Card) CardCode {
+	code := CardCode(int(c.Suit)*13 + int(c.Rank) - 1)
+	if c.FaceUp {
+		code |= faceUpBit
+	}
+	return code
+}
+
+// Card unpacks the code.
+func (c CardCode) Card() Card {
+	n := int(c &^ faceUpBit)
+	return Card{Suit: Suit(n / 13), Rank: Rank(n%13 + 1), FaceUp: c.FaceUp()}
+}
+
+// FaceUp reports whether the card is face up.
+func (c CardCode) FaceUp() bool {
+	return c&faceUpBit != 0
+}
+
+// ID returns the card number (0-51), ignoring whether it is face up.
+func (c CardCode) ID() int {
+	return int(c &^ faceUpBit)
+}
+
+// statePile is a pile stored in a fixed array. Slots past n are always zero
+// so that comparing two States with == compares only the cards in play.
+type statePile struct {
+	n     uint8
+	cards [maxPileLen]CardCode
+}
+
+// State is a compact, immutable-by-value snapshot of the board. It holds no
+// pointers: assigning a State copies it, and two States are equal exactly when
+// == says so. Use it for search, undo snapshots and background analysis; use
+// Game for play.
+type State struct {
+	piles [numPiles]statePile
+}
+
+// State returns a compact snapshot of the board.
+func (g *Game) State() State {
+	var s State
+	for i := 0; i < numPiles; i++ {
+		pile := g.GetPile(i)
+		s.piles[i].n = uint8(len(pile.Cards))
+		for j, c := range pile.Cards {
+			s.piles[i].cards[j] = CodeOf(*c)
+		}
+	}
+	return s
+}
+
+// FromState creates a game with the board described by s. The new game is
+// played under the default rules and has no history and no selection.
+func FromState(s State) *Game {
+	g := &Game{Rules: DefaultRules(), ActivePile: -1, ActiveCard: -1}
+	g.setBoard(s)
+	g.CheckWinCondition()
+	return g
+}
+
+// setBoard replaces every pile with freshly allocated cards from s. All cards
+// and pile slices share two allocations; each slice is capped at its length so
+// an append to one pile never writes into the next.
+func (g *Game) setBoard(s State) {
+	backing := make([]Card, 0, 52)
+	ptrs := make([]*Card, 0, 52)
+	for i := 0; i < numPiles; i++ {
+		p := &s.piles[i]
+		start := len(ptrs)
+		for j := 0; j < int(p.n); j++ {
+			backing = append(backing, p.cards[j].Card())
+			ptrs = append(ptrs, &backing[len(backing)-1])
+		}
+		g.GetPile(i).Cards = ptrs[start:len(ptrs):len(ptrs)]
+	}
+}
+
+// Clone returns a copy of the state. States are values, so this is a plain copy.
+func (s State) Clone() State {
+	return s
+}
+
+// Equal reports whether two states describe the same board.
+func (s State) Equal(o State) bool {
+	return s == o
+}
+
+// Len returns the number of cards in pile i.
+func (s *State) Len(i int) int {
+	return int(s.piles[i].n)
+}
+
+// Pile returns the cards in pile i, bottom first. The slice aliases the
+// state, so callers must not modify it.
+func (s *State) Pile(i int) []CardCode {
+	p := &s.piles[i]
+	return p.cards[:p.n]
+}
+
+// zobrist holds a random key per (pile, position, card, face) combination.
+var zobrist [numPiles][maxPileLen][104]uint64
+
+func init() {
+	seed := uint64(0x5eed)
+	for i := range zobrist {
+		for j := range zobrist[i] {
+			for k := range zobrist[i][j] {
+				zobrist[i][j][k] = splitmix64(&seed)
+			}
+		}
+	}
+}
+
+// Hash returns a Zobrist hash of the board. Equal states always hash equally.
+func (s *State) Hash() uint64 {
+	var h uint64
+	for i := range s.piles {
+		p := &s.piles[i]
+		for j := 0; j < int(p.n); j++ {
+			c := p.cards[j]
+			k := c.ID()
+			if c.FaceUp() {
+				k += 52
+			}
+			h ^= zobrist[i][j][k]
+		}
+	}
+	return h
+}
+
+// Clone returns a deep copy of the game. The copy shares no cards with g, so
+// moves on one never affect the other, and it has no observers.
+func (g *Game) Clone() *Game {
+	c := *g
+	c.observers = nil
+	c.setBoard(g.State())
+	c.History = append([]Move(nil), g.History...)
+	c.undo = append([]snapshot(nil), g.undo...)
+	return &c
+}
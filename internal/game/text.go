@@ -0,0 +1,60 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// faceDownText stands in for a face-down card in plain-text output.
+const faceDownText = "##"
+
+// String renders the board as plain text, one pile per line:
+//
+//	S: [24]  W: 7♦
+//	F1: A♠  F2: --  F3: --  F4: --
+//	T1: 5♥
+//	T2: ## 9♣
+//	...
+func (g *Game) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "S: [%d]  W: %s", len(g.Stock.Cards), topText(&g.Waste))
+	if n := len(g.Waste.Cards); n > 1 {
+		fmt.Fprintf(&b, " [%d]", n)
+	}
+	b.WriteString("\n")
+
+	for i := range g.Foundations {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		fmt.Fprintf(&b, "%s: %s", PileName(FoundationPile1+i), topText(&g.Foundations[i]))
+	}
+	b.WriteString("\n")
+
+	for i := range g.Tableaus {
+		b.WriteString(PileName(TableauPile1+i) + ":")
+		for _, c := range g.Tableaus[i].Cards {
+			b.WriteString(" " + cardText(c))
+		}
+		b.WriteString("\n")
+	}
+	if g.IsWon {
+		b.WriteString("Won!\n")
+	}
+	return b.String()
+}
+
+func topText(p *Pile) string {
+	if c := p.Peek(); c != nil {
+		return cardText(c)
+	}
+	return "--"
+}
+
+func cardText(c *Card) string {
+	if !c.FaceUp {
+		return faceDownText
+	}
+	return c.String()
+}
@@ -0,0 +1,74 @@
+package game
+
+// snapshot is everything a move can change, saved so the move can be undone.
+type snapshot struct {
+	board    State
+	recycles int
+	isWon    bool
+	score    int
+	peeks    int
+}
+
+// saveUndo records the board before a move is made.
+func (g *Game) saveUndo() {
+	g.undo = append(g.undo, snapshot{board: g.State(), recycles: g.Recycles, isWon: g.IsWon, score: g.Score, peeks: g.Peeks})
+}
+
+// CanUndo reports whether there is a move to take back.
+func (g *Game) CanUndo() bool {
+	return len(g.undo) > 0 && len(g.History) > 0
+}
+
+// Undo takes back the last move, restoring the board as it was before it and
+// removing the move from History. Reports false if there was nothing to undo.
+// Card pointers previously taken from the piles no longer refer to the board.
+func (g *Game) Undo() bool {
+	if !g.CanUndo() {
+		return false
+	}
+	s := g.undo[len(g.undo)-1]
+	g.undo = g.undo[:len(g.undo)-1]
+	move := g.History[len(g.History)-1]
+	g.History = g.History[:len(g.History)-1]
+
+	// Cards the move turned up may go back face down or into the stock, but
+	// the player has seen them
+	switch move.Kind {
+	case MoveDraw:
+		g.markFaceUpSeen(WastePile)
+	case MoveTransfer:
+		g.markFaceUpSeen(move.From)
+		g.markFaceUpSeen(move.To)
+	}
+	g.setBoard(s.board)
+	g.Recycles = s.recycles
+	g.IsWon = s.isWon
+	// Points for the move come back, but peeks made since are still paid for
+	g.Score = s.score + PointsPeek*(g.Peeks-s.peeks)
+	g.emit(MoveUndone{Move: move})
+	return true
+}
+
+// Seen reports whether the player has looked at the hidden card c, either
+// with Peek or face up before an undo hid it again.
+func (g *Game) Seen(c Card) bool {
+	return g.seen&cardBit(c) != 0
+}
+
+func (g *Game) markSeen(c Card) {
+	g.seen |= cardBit(c)
+}
+
+// markFaceUpSeen marks the face-up cards of a pile as seen.
+func (g *Game) markFaceUpSeen(pileIndex int) {
+	for _, c := range g.GetPile(pileIndex).Cards {
+		if c.FaceUp {
+			g.markSeen(*c)
+		}
+	}
+}
+
+// cardBit gives each of the 52 cards its own bit.
+func cardBit(c Card) uint64 {
+	return 1 << (uint(c.Suit)*13 + uint(c.Rank) - 1)
+}
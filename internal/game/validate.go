@@ -0,0 +1,85 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the board invariants every legal game keeps:
+//   - exactly 52 unique cards across all 13 piles
+//   - each foundation is a single suit built up from Ace in order
+//   - in each tableau, face-down cards sit below all face-up cards
+//   - the stock is entirely face down and the waste entirely face up
+//
+// It returns every violation found, joined, or nil if the board is consistent.
+func (g *Game) Validate() error {
+	var errs []error
+	var seen [52]bool
+	total := 0
+
+	for i := StockPile; i <= TableauPile7; i++ {
+		for j, c := range g.GetPile(i).Cards {
+			if c == nil {
+				errs = append(errs, fmt.Errorf("%s[%d]: nil card", PileName(i), j))
+				continue
+			}
+			if c.Suit < Spades || c.Suit > Clubs || c.Rank < Ace || c.Rank > King {
+				errs = append(errs, fmt.Errorf("%s[%d]: invalid card %+v", PileName(i), j, *c))
+				continue
+			}
+			total++
+			id := CodeOf(*c).ID()
+			if seen[id] {
+				errs = append(errs, fmt.Errorf("%s[%d]: duplicate %s%s", PileName(i), j, c.Rank, c.Suit))
+			}
+			seen[id] = true
+		}
+	}
+	if total != 52 {
+		errs = append(errs, fmt.Errorf("board holds %d cards, want 52", total))
+	}
+
+	for f := range g.Foundations {
+		pile := g.Foundations[f].Cards
+		for j, c := range pile {
+			if c == nil {
+				continue
+			}
+			switch {
+			case !c.FaceUp:
+				errs = append(errs, fmt.Errorf("%s[%d]: face-down card on foundation", PileName(FoundationPile1+f), j))
+			case c.Rank != Rank(j+1):
+				errs = append(errs, fmt.Errorf("%s[%d]: %s%s out of order", PileName(FoundationPile1+f), j, c.Rank, c.Suit))
+			case pile[0] != nil && c.Suit != pile[0].Suit:
+				errs = append(errs, fmt.Errorf("%s[%d]: %s%s on a %s foundation", PileName(FoundationPile1+f), j, c.Rank, c.Suit, pile[0].Suit))
+			}
+		}
+	}
+
+	for t := range g.Tableaus {
+		faceUp := false
+		for j, c := range g.Tableaus[t].Cards {
+			if c == nil {
+				continue
+			}
+			if c.FaceUp {
+				faceUp = true
+			} else if faceUp {
+				errs = append(errs, fmt.Errorf("%s[%d]: face-down card above a face-up card", PileName(TableauPile1+t), j))
+			}
+		}
+	}
+
+	for j, c := range g.Stock.Cards {
+		if c != nil && c.FaceUp {
+			errs = append(errs, fmt.Errorf("S[%d]: face-up card in stock", j))
+		}
+	}
+	for j, c := range g.Waste.Cards {
+		if c != nil && !c.FaceUp {
+			errs = append(errs, fmt.Errorf("W[%d]: face-down card in waste", j))
+		}
+	}
+
+	return errors.Join(errs...)
+}
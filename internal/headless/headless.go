@@ -0,0 +1,182 @@
+// Package headless plays games from plain-text commands, without a terminal UI,
+// so games can be scripted, piped between tools and reproduced in tests.
+package headless
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// Help describes the commands Run understands.
+const Help = `Commands:
+  draw, d              draw from the stock (recycles the waste when the stock is empty)
+  move SRC DST, m      move cards, e.g. "move W T3", "move T5:2 T1", "move T2 F1"
+  undo, u              take back the last move
+  show, s              print the board
+  export               print the game in notation form
+  chance               estimate the win chance, overall and after each legal move
+  help                 print this help
+  quit, exit           stop reading commands
+Moves in notation form (D, R, W→T3, T5:2->F1) are accepted too. A tableau source
+without ":index" means its top card. A line of several moves is played only if
+every move on it is legal. Blank lines and lines starting with # are ignored.
+`
+
+// Run reads one command per line from in and applies it to g. The board is
+// written to out at the start and after every command that changes it. Failed
+// commands are reported on out and do not stop the run; Run returns how many
+// failed, and a non-nil error only if reading or writing fails.
+func Run(g *game.Game, in io.Reader, out io.Writer) (failed int, err error) {
+	w := bufio.NewWriter(out)
+	defer func() {
+		if ferr := w.Flush(); err == nil {
+			err = ferr
+		}
+	}()
+
+	fmt.Fprint(w, g)
+	scanner := bufio.NewScanner(in)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n> %s\n", line)
+		show, quit, cmdErr := execute(g, line, w)
+		if cmdErr != nil {
+			failed++
+			fmt.Fprintf(w, "error: line %d: %v\n", lineNo, cmdErr)
+			continue
+		}
+		if quit {
+			break
+		}
+		if show {
+			fmt.Fprint(w, g)
+		}
+	}
+	return failed, scanner.Err()
+}
+
+// execute runs a single command line. show reports whether the board should be
+// printed afterwards; quit reports whether to stop reading.
+func execute(g *game.Game, line string, w io.Writer) (show, quit bool, err error) {
+	fields := strings.Fields(line)
+	cmd, args := strings.ToLower(fields[0]), fields[1:]
+
+	switch cmd {
+	case "draw", "d":
+		if err := expectArgs(args, 0); err != nil {
+			return false, false, err
+		}
+		if len(g.Stock.Cards) > 0 {
+			return true, false, g.Apply(game.Move{Kind: game.MoveDraw})
+		}
+		return true, false, g.Apply(game.Move{Kind: game.MoveRecycle})
+
+	case "move", "m":
+		if err := expectArgs(args, 2); err != nil {
+			return false, false, err
+		}
+		m, err := g.ParseMove(args[0] + "→" + args[1])
+		if err != nil {
+			return false, false, err
+		}
+		return true, false, g.Apply(m)
+
+	case "undo", "u":
+		if err := expectArgs(args, 0); err != nil {
+			return false, false, err
+		}
+		if !g.Undo() {
+			return false, false, fmt.Errorf("nothing to undo")
+		}
+		return true, false, nil
+
+	case "show", "s":
+		return true, false, expectArgs(args, 0)
+
+	case "export":
+		_, err := io.WriteString(w, g.Export())
+		return false, false, err
+
+	case "chance":
+		if err := expectArgs(args, 0); err != nil {
+			return false, false, err
+		}
+		return false, false, writeChance(w, g)
+
+	case "help":
+		_, err := io.WriteString(w, Help)
+		return false, false, err
+
+	case "quit", "exit":
+		return false, true, nil
+	}
+
+	// Anything else must be moves in notation form. They are tried on a copy
+	// first, so a line is played whole or not at all.
+	trial := g.Clone()
+	moves := make([]game.Move, 0, len(fields))
+	for _, tok := range fields {
+		m, err := trial.ParseMove(tok)
+		if err != nil {
+			return false, false, fmt.Errorf("unknown command %q", tok)
+		}
+		if err := trial.Apply(m); err != nil {
+			return false, false, fmt.Errorf("%s: %w; no move on the line was played", tok, err)
+		}
+		moves = append(moves, m)
+	}
+	for _, m := range moves {
+		if err := g.Apply(m); err != nil {
+			return true, false, fmt.Errorf("%s: %w", m, err)
+		}
+	}
+	return true, false, nil
+}
+
+// Win chance estimate size for the chance command.
+const (
+	chanceSamples = 32
+	chanceNodes   = 5000
+)
+
+// writeChance prints the estimated win chance of the position and of each legal move.
+func writeChance(w io.Writer, g *game.Game) error {
+	total, moves := analysis.WinChance(context.Background(), g, analysis.EstimateOptions{
+		Samples:  chanceSamples,
+		MaxNodes: chanceNodes,
+		Seed:     g.Seed,
+		PerMove:  true,
+	})
+	fmt.Fprintf(w, "position  %s\n", chanceText(total))
+	for _, m := range moves {
+		fmt.Fprintf(w, "%-9s %s\n", m.Move, chanceText(m.Estimate))
+	}
+	return nil
+}
+
+func chanceText(e analysis.Estimate) string {
+	p, ok := e.Chance()
+	if !ok {
+		return "?"
+	}
+	return fmt.Sprintf("%3.0f%% (%d of %d samples won, %d undecided)", 100*p, e.Wins, e.Samples, e.Unknown)
+}
+
+func expectArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+	return nil
+}
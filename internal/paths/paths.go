@@ -0,0 +1,54 @@
+// Package paths locates the files solitaire-tui keeps on disk.
+package paths
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// appDir is the directory name used under the XDG base directories.
+const appDir = "solitaire-tui"
+
+// DataDir returns the directory for persistent game data such as statistics,
+// following $XDG_DATA_HOME and falling back to ~/.local/share.
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, appDir), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", appDir), nil
+}
+
+// DataFile returns the path of name inside DataDir.
+func DataFile(name string) (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// ConfigDir returns the directory for the player's settings, following
+// $XDG_CONFIG_HOME and falling back to ~/.config.
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appDir), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", appDir), nil
+}
+
+// ConfigFile returns the path of name inside ConfigDir.
+func ConfigFile(name string) (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
@@ -0,0 +1,97 @@
+// Package records keeps finished games on disk in solitaire notation so they
+// can be replayed later.
+package records
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/paths"
+)
+
+// DirName is the name of the game records directory inside the data directory.
+const DirName = "games"
+
+// ext is the extension of game record files.
+const ext = ".sol"
+
+// stamp is the layout of the save time that starts every file name.
+const stamp = "20060102-150405"
+
+// Entry describes a saved game.
+type Entry struct {
+	Path  string
+	Saved time.Time
+	Seed  int64
+	Won   bool
+	Moves int
+}
+
+// DefaultDir returns the location of the game records in the data directory.
+func DefaultDir() (string, error) {
+	return paths.DataFile(DirName)
+}
+
+// Save writes g to a new file in dir, named after the time it was saved and
+// its seed, and returns the file's path.
+func Save(dir string, g *game.Game, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%d%s", now.UTC().Format(stamp), g.Seed, ext)
+	path := filepath.Join(dir, name)
+	return path, os.WriteFile(path, []byte(g.Export()), 0o644)
+}
+
+// List returns the games saved in dir, newest first. A missing directory
+// holds no games; files that cannot be read are skipped.
+func List(dir string) ([]Entry, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ext) {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		g, err := Load(path)
+		if err != nil {
+			continue
+		}
+		var saved time.Time
+		if len(f.Name()) > len(stamp) {
+			saved, _ = time.Parse(stamp, f.Name()[:len(stamp)])
+		}
+		entries = append(entries, Entry{Path: path, Saved: saved, Seed: g.Seed, Won: g.IsWon, Moves: len(g.History)})
+	}
+	// Names start with the save time, so they sort oldest first
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path > entries[j].Path })
+	return entries, nil
+}
+
+// Load reads the game saved at path.
+func Load(path string) (*game.Game, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	g, err := game.Import(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return g, nil
+}
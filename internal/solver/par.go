@@ -0,0 +1,143 @@
+package solver
+
+import (
+	"context"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// ParOptions control a search for the shortest solution.
+type ParOptions struct {
+	Options
+
+	// Slack is how much longer than the shortest solution the answer may be,
+	// as a factor: 1 (or zero) asks for a shortest solution, 1.25 accepts one
+	// up to a quarter longer. Looser bounds finish far sooner.
+	Slack float64
+}
+
+// ParResult is the outcome of a search for the shortest solution.
+type ParResult struct {
+	Result
+
+	// Proven reports whether the search finished, so Moves is within Slack
+	// of the shortest solution. Otherwise Moves is the shortest one found
+	// before the budget ran out.
+	Proven bool
+}
+
+// Par searches for the shortest win from the current position of g, which
+// is not modified. Solve supplies a first solution; a depth-first branch and
+// bound then looks for shorter ones, cutting off lines that cannot beat the
+// best so far even if every remaining card went straight up. Draws and
+// recycles count as moves. Like Solve it skips lines it considers useless,
+// so the shortest solution is the shortest among those it searches. The
+// nodes of both phases share one budget.
+func Par(ctx context.Context, g *game.Game, opts ParOptions) ParResult {
+	if opts.MaxNodes <= 0 {
+		opts.MaxNodes = DefaultMaxNodes
+	}
+	if opts.Slack < 1 {
+		opts.Slack = 1
+	}
+
+	first := Solve(ctx, g, opts.Options)
+	if first.Status != Solved {
+		return ParResult{Result: first, Proven: first.Status == NoWinFound}
+	}
+
+	s := &parSearch{
+		search: search{
+			ctx:      ctx,
+			g:        g.Clone(),
+			maxNodes: opts.MaxNodes,
+			nodes:    first.Nodes,
+			progress: opts.Progress,
+		},
+		slack: opts.Slack,
+		base:  len(g.History),
+		best:  first.Moves,
+		depth: make(map[uint64]int),
+	}
+	s.dfs(0)
+	return ParResult{
+		Result: Result{Status: Solved, Moves: s.best, Nodes: s.nodes},
+		Proven: !s.stopped,
+	}
+}
+
+type parSearch struct {
+	search
+	slack float64
+	base  int            // Length of the history before the search
+	best  []game.Move    // Shortest solution so far
+	depth map[uint64]int // Fewest moves each position has been reached in
+}
+
+// dfs looks for a win from the current position, depth moves in, that is
+// shorter than the best so far by the slack factor.
+func (s *parSearch) dfs(depth int) {
+	if s.g.IsWon {
+		s.best = append([]game.Move(nil), s.g.History[s.base:]...)
+		return
+	}
+	if s.slack*float64(depth+remaining(s.g)) >= float64(len(s.best)) {
+		return
+	}
+	key := s.key()
+	if d, ok := s.depth[key]; ok && d <= depth {
+		return
+	}
+	if !s.expand() {
+		return
+	}
+	s.depth[key] = depth
+
+	for _, m := range Candidates(s.g) {
+		if s.g.Apply(m) != nil {
+			continue
+		}
+		s.dfs(depth + 1)
+		s.g.Undo()
+		if s.stopped {
+			return
+		}
+	}
+}
+
+// remaining is a lower bound on the moves left to win. Every card not yet
+// on a foundation has to be moved there, every card in the stock has to be
+// drawn first unless the rules let it be played directly, and a column with
+// a card above a lower card of its own suit needs at least one move that
+// takes it out of the way.
+func remaining(g *game.Game) int {
+	n := 52
+	for _, f := range g.Foundations {
+		n -= len(f.Cards)
+	}
+	if !g.Rules.StockToTableau {
+		draw := max(g.Rules.DrawCount, 1)
+		n += (len(g.Stock.Cards) + draw - 1) / draw
+	}
+	for _, t := range g.Tableaus {
+		if blocked(t.Cards) {
+			n++
+		}
+	}
+	return n
+}
+
+// blocked reports whether some card in cards lies above a lower card of the
+// same suit, which has to reach the foundation first.
+func blocked(cards []*game.Card) bool {
+	var lowest [4]game.Rank
+	for _, c := range cards {
+		if low := lowest[c.Suit]; low != 0 && low < c.Rank {
+			return true
+		}
+		if lowest[c.Suit] == 0 || c.Rank < lowest[c.Suit] {
+			lowest[c.Suit] = c.Rank
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+// Package solver searches for winning move sequences. It sees the whole
+// board, face-down cards included, so it answers whether a deal can be won
+// by a player who knows where every card is.
+package solver
+
+import (
+	"context"
+	"sort"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// Status is the outcome of a search.
+type Status int
+
+const (
+	// Unknown means the search ran out of budget or was cancelled first.
+	Unknown Status = iota
+	// Solved means a winning sequence was found.
+	Solved
+	// NoWinFound means the search ran dry without a win. Solve prunes moves
+	// it judges useless, and that pruning is not proven safe, so the deal may
+	// still be winnable by a line the search skipped.
+	NoWinFound
+)
+
+// String returns a lower-case description of the status.
+func (s Status) String() string {
+	switch s {
+	case Solved:
+		return "solved"
+	case NoWinFound:
+		return "no win found"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultMaxNodes is the node budget used when Options.MaxNodes is zero.
+const DefaultMaxNodes = 200_000
+
+// Options control a search.
+type Options struct {
+	MaxNodes int // Positions to expand before giving up; DefaultMaxNodes if zero
+
+	// Progress, if set, is called from the searching goroutine with the
+	// number of positions expanded so far, every ProgressInterval positions.
+	Progress func(nodes int)
+}
+
+// ProgressInterval is how many positions pass between Progress calls.
+const ProgressInterval = 1024
+
+// Result is the outcome of a search.
+type Result struct {
+	Status Status
+	Moves  []game.Move // The winning sequence from the searched position when Solved
+	Nodes  int         // Positions expanded
+}
+
+// Solve searches for a win from the current position of g, which is not
+// modified. The search is depth-first over legal moves with a transposition
+// table, plays safe foundation moves without branching and skips moves that
+// only shuffle cards between equivalent places, so NoWinFound means no win
+// exists outside those pruned lines, not that the deal cannot be won. Cancelling ctx stops the search with
+// status Unknown.
+func Solve(ctx context.Context, g *game.Game, opts Options) Result {
+	if opts.MaxNodes <= 0 {
+		opts.MaxNodes = DefaultMaxNodes
+	}
+	s := &search{
+		ctx:      ctx,
+		g:        g.Clone(),
+		maxNodes: opts.MaxNodes,
+		progress: opts.Progress,
+		seen:     make(map[uint64]struct{}),
+	}
+
+	found := s.dfs()
+	r := Result{Nodes: s.nodes}
+	switch {
+	case found:
+		r.Status = Solved
+		r.Moves = s.g.History[len(g.History):]
+	case !s.stopped:
+		r.Status = NoWinFound
+	}
+	return r
+}
+
+type search struct {
+	ctx      context.Context
+	g        *game.Game
+	maxNodes int
+	nodes    int
+	stopped  bool // Budget exhausted or cancelled
+	progress func(nodes int)
+	seen     map[uint64]struct{}
+}
+
+// dfs reports whether the position can be won. On success the winning moves
+// are left applied to s.g.
+func (s *search) dfs() bool {
+	if s.g.IsWon {
+		return true
+	}
+	if !s.expand() {
+		return false
+	}
+
+	key := s.key()
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	s.seen[key] = struct{}{}
+
+	for _, m := range Candidates(s.g) {
+		if s.g.Apply(m) != nil {
+			continue
+		}
+		if s.dfs() {
+			return true
+		}
+		s.g.Undo()
+		if s.stopped {
+			return false
+		}
+	}
+	return false
+}
+
+// expand counts a position as expanded. It reports false, marking the search
+// stopped, once the budget is spent or ctx is cancelled.
+func (s *search) expand() bool {
+	if s.stopped {
+		return false
+	}
+	if s.nodes >= s.maxNodes {
+		s.stopped = true
+		return false
+	}
+	s.nodes++
+	if s.nodes%ProgressInterval == 0 {
+		if s.ctx.Err() != nil {
+			s.stopped = true
+			return false
+		}
+		if s.progress != nil {
+			s.progress(s.nodes)
+		}
+	}
+	return true
+}
+
+// key identifies the position, including the redeals used when they are limited.
+func (s *search) key() uint64 {
+	state := s.g.State()
+	h := state.Hash()
+	if s.g.Rules.Redeals != game.UnlimitedRedeals {
+		h ^= uint64(s.g.Recycles+1) * 0x9e3779b97f4a7c15
+	}
+	return h
+}
+
+// Candidates returns the legal moves of g worth searching, most promising
+// first. A safe move to a foundation, one no other card could ever need to
+// build on, is returned alone.
+func Candidates(g *game.Game) []game.Move {
+	legal := g.LegalMoves()
+	type scored struct {
+		m     game.Move
+		score int
+	}
+	var moves []scored
+	for _, m := range legal {
+		score, ok := Score(g, m)
+		if !ok {
+			continue
+		}
+		if m.Kind == game.MoveTransfer && isFoundation(m.To) && safeToFoundation(g, m) {
+			return []game.Move{m}
+		}
+		moves = append(moves, scored{m, score})
+	}
+
+	sort.SliceStable(moves, func(i, j int) bool { return moves[i].score > moves[j].score })
+	out := make([]game.Move, len(moves))
+	for i, s := range moves {
+		out[i] = s.m
+	}
+	return out
+}
+
+// Score rates a legal move for ordering: foundation moves first, then moves
+// that turn up a face-down card, then plays from the waste, then draws, with
+// moves off the foundations last. ok is false for moves that cannot help:
+// a whole column moved to an empty column, or a stack moved between two
+// equivalent parents when the card it leaves behind cannot then go up.
+func Score(g *game.Game, m game.Move) (score int, ok bool) {
+	switch m.Kind {
+	case game.MoveDraw, game.MoveRecycle:
+		return 20, true
+	}
+
+	src := g.GetPile(m.From).Cards
+	dst := g.GetPile(m.To).Cards
+	switch {
+	case isFoundation(m.To):
+		return 100, true
+	case isFoundation(m.From):
+		return 0, true
+	case m.From == game.WastePile || m.From == game.StockPile:
+		return 50, true
+	}
+
+	// Tableau to tableau
+	if m.Index == 0 {
+		if len(dst) == 0 {
+			return 0, false
+		}
+		return 60, true // Empties a column
+	}
+	below := src[m.Index-1]
+	if !below.FaceUp {
+		return 80 + m.Index, true // Turns up a card; deeper columns first
+	}
+	if canGoUp(g, *below) {
+		return 30, true
+	}
+	return 0, false
+}
+
+// canGoUp reports whether c could be played to a foundation now.
+func canGoUp(g *game.Game, c game.Card) bool {
+	for _, f := range g.Foundations {
+		top := f.Peek()
+		if top == nil && c.Rank == game.Ace {
+			return true
+		}
+		if top != nil && top.Suit == c.Suit && top.Rank+1 == c.Rank {
+			return true
+		}
+	}
+	return false
+}
+
+// SafeMove returns a legal move of a card to a foundation that no other card
+// could ever need to build on, if there is one. Such a move never costs the
+// game, so it can be played without asking.
+func SafeMove(g *game.Game) (game.Move, bool) {
+	for _, m := range g.LegalMoves() {
+		if m.Kind == game.MoveTransfer && isFoundation(m.To) && safeToFoundation(g, m) {
+			return m, true
+		}
+	}
+	return game.Move{}, false
+}
+
+// safeToFoundation reports whether the card moved by m can never be needed
+// on the tableau again: every card that could build on it, the opposite
+// colour one rank lower, is already on a foundation.
+func safeToFoundation(g *game.Game, m game.Move) bool {
+	c := g.GetPile(m.From).Cards[m.Index]
+	if c.Rank <= game.Two {
+		return true
+	}
+	opposite := 0
+	for _, f := range g.Foundations {
+		top := f.Peek()
+		if top != nil && top.Suit.Color() != c.Suit.Color() && top.Rank >= c.Rank-1 {
+			opposite++
+		}
+	}
+	return opposite == 2
+}
+
+func isFoundation(i int) bool {
+	return i >= game.FoundationPile1 && i <= game.FoundationPile4
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/paths"
+)
+
+// DailyFileName is the name of the daily challenge log inside the data directory.
+const DailyFileName = "daily.json"
+
+// DailyEntry is the result of one day's challenge.
+type DailyEntry struct {
+	Solved bool `json:"solved"`
+	Moves  int  `json:"moves,omitempty"`
+	Secs   int  `json:"secs,omitempty"`
+	// Cards is the most cards put on the foundations in a resigned attempt.
+	Cards int `json:"cards,omitempty"`
+}
+
+// DailyLog records daily challenge results, kept apart from the regular statistics.
+type DailyLog struct {
+	Days map[string]*DailyEntry `json:"days"`
+
+	path string
+}
+
+// DayKey returns the log key for the UTC date of t, e.g. "2026-01-17".
+func DayKey(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// DefaultDailyPath returns the location of the daily log in the data directory.
+func DefaultDailyPath() (string, error) {
+	return paths.DataFile(DailyFileName)
+}
+
+// LoadDaily reads the daily log from path. A missing file yields an empty log.
+func LoadDaily(path string) (*DailyLog, error) {
+	l := &DailyLog{Days: map[string]*DailyEntry{}, path: path}
+	if _, err := readFile(path, l); err != nil {
+		return nil, err
+	}
+	if l.Days == nil {
+		l.Days = map[string]*DailyEntry{}
+	}
+	return l, nil
+}
+
+// Save writes the log back to the file it was loaded from.
+func (l *DailyLog) Save() error {
+	return writeFile(l.path, l)
+}
+
+// Entry returns the result for the day of t, or nil if it was never attempted.
+func (l *DailyLog) Entry(t time.Time) *DailyEntry {
+	return l.Days[DayKey(t)]
+}
+
+// Attempt records that the challenge for the day of t was started.
+func (l *DailyLog) Attempt(t time.Time) {
+	if l.Entry(t) == nil {
+		l.Days[DayKey(t)] = &DailyEntry{}
+	}
+}
+
+// Solve records a win of the challenge for the day of t, keeping the best result.
+func (l *DailyLog) Solve(t time.Time, elapsed time.Duration, moves int) {
+	l.Attempt(t)
+	e := l.Entry(t)
+	secs := int(elapsed.Round(time.Second) / time.Second)
+	if !e.Solved || moves < e.Moves {
+		e.Moves = moves
+		e.Secs = secs
+	}
+	e.Solved = true
+}
+
+// Resign records that an attempt at the challenge for the day of t was given
+// up with cards on the foundations, keeping the best attempt.
+func (l *DailyLog) Resign(t time.Time, cards int) {
+	l.Attempt(t)
+	e := l.Entry(t)
+	e.Cards = max(e.Cards, cards)
+}
@@ -0,0 +1,300 @@
+// Package stats keeps persistent win/loss statistics per game mode.
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/paths"
+)
+
+// FileName is the name of the statistics file inside the data directory.
+const FileName = "stats.json"
+
+// Record holds the statistics for a single variant and draw mode.
+type Record struct {
+	Played        int `json:"played"`
+	Won           int `json:"won"`
+	CurrentStreak int `json:"current_streak"`
+	LongestStreak int `json:"longest_streak"`
+	// PendingStreak holds the streak while a game is in progress. The current
+	// streak is zeroed when a game starts, so an abandoned game counts as a loss.
+	PendingStreak  int `json:"pending_streak"`
+	FastestWinSecs int `json:"fastest_win_secs"`
+	FewestMoves    int `json:"fewest_moves"`
+	TotalWinSecs   int `json:"total_win_secs"`
+	ParOrBetter    int `json:"par_or_better"` // Wins in no more moves than the deal's par
+	BestScore      int `json:"best_score"`    // Highest score of a win without peeking
+	PeekedWins     int `json:"peeked_wins"`   // Wins in which hidden cards were peeked at
+	Resigned       int `json:"resigned"`
+	ResignedCards  int `json:"resigned_cards"` // Foundation cards over all resigned games
+}
+
+// Lost returns the number of played games that were not won.
+func (r Record) Lost() int {
+	return r.Played - r.Won
+}
+
+// WinRate returns the percentage of played games that were won.
+func (r Record) WinRate() float64 {
+	if r.Played == 0 {
+		return 0
+	}
+	return 100 * float64(r.Won) / float64(r.Played)
+}
+
+// AverageWinTime returns the mean time taken to win a game.
+func (r Record) AverageWinTime() time.Duration {
+	if r.Won == 0 {
+		return 0
+	}
+	return time.Duration(r.TotalWinSecs/r.Won) * time.Second
+}
+
+// AverageResignedCards returns the mean number of cards on the foundations
+// when a game was resigned.
+func (r Record) AverageResignedCards() float64 {
+	if r.Resigned == 0 {
+		return 0
+	}
+	return float64(r.ResignedCards) / float64(r.Resigned)
+}
+
+// Stats is the full statistics file, keyed by ModeKey.
+type Stats struct {
+	Modes map[string]*Record `json:"modes"`
+
+	path string
+}
+
+// ModeKey returns the key statistics are kept under, e.g. "Klondike draw-1".
+func ModeKey(variant string, drawCount int) string {
+	return fmt.Sprintf("%s draw-%d", variant, drawCount)
+}
+
+// DefaultPath returns the location of the statistics file in the data directory.
+func DefaultPath() (string, error) {
+	return paths.DataFile(FileName)
+}
+
+// Load reads statistics from path. A missing file yields empty statistics; an
+// unreadable one yields an error so it is never overwritten.
+func Load(path string) (*Stats, error) {
+	s := &Stats{Modes: map[string]*Record{}, path: path}
+	if _, err := readFile(path, s); err != nil {
+		return nil, err
+	}
+	if s.Modes == nil {
+		s.Modes = map[string]*Record{}
+	}
+	return s, nil
+}
+
+// Save writes the statistics back to the file they were loaded from.
+func (s *Stats) Save() error {
+	return writeFile(s.path, s)
+}
+
+// writeFile saves v as indented JSON at path. An empty path means the data is
+// kept in memory only.
+func writeFile(path string, v any) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so a crash never leaves a truncated file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readFile loads JSON from path into v. Reports false if the file does not exist.
+func readFile(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+	return true, nil
+}
+
+// Mode returns the record for key, creating it if needed.
+func (s *Stats) Mode(key string) *Record {
+	r, ok := s.Modes[key]
+	if !ok {
+		r = &Record{}
+		s.Modes[key] = r
+	}
+	return r
+}
+
+// Begin records that a game in the given mode has started.
+func (s *Stats) Begin(key string) {
+	r := s.Mode(key)
+	r.Played++
+	r.PendingStreak = r.CurrentStreak
+	r.CurrentStreak = 0
+}
+
+// Win records that the game started with Begin was won.
+func (s *Stats) Win(key string, elapsed time.Duration, moves int) {
+	r := s.Mode(key)
+	r.Won++
+	r.CurrentStreak = r.PendingStreak + 1
+	r.PendingStreak = 0
+	r.LongestStreak = max(r.LongestStreak, r.CurrentStreak)
+
+	secs := int(elapsed.Round(time.Second) / time.Second)
+	if r.FastestWinSecs == 0 || secs < r.FastestWinSecs {
+		r.FastestWinSecs = secs
+	}
+	if r.FewestMoves == 0 || moves < r.FewestMoves {
+		r.FewestMoves = moves
+	}
+	r.TotalWinSecs += secs
+}
+
+// WinAgainstPar counts the game just won if it took no more moves than its
+// par, the shortest solution the solver found for the deal.
+func (s *Stats) WinAgainstPar(key string, moves, par int) {
+	if moves <= par {
+		s.Mode(key).ParOrBetter++
+	}
+}
+
+// WinScore records the score of the game just won. Only wins without peeks
+// can set the best score; the others are counted apart.
+func (s *Stats) WinScore(key string, score, peeks int) {
+	r := s.Mode(key)
+	if peeks > 0 {
+		r.PeekedWins++
+		return
+	}
+	if score > r.BestScore {
+		r.BestScore = score
+	}
+}
+
+// Resign records that the game started with Begin was given up with cards on
+// the foundations. It stays counted as a loss.
+func (s *Stats) Resign(key string, cards int) {
+	r := s.Mode(key)
+	r.Resigned++
+	r.ResignedCards += cards
+}
+
+// keys returns the mode keys in a stable order.
+func (s *Stats) keys() []string {
+	keys := make([]string, 0, len(s.Modes))
+	for k := range s.Modes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteText writes a human-readable table of every mode.
+func (s *Stats) WriteText(w io.Writer) error {
+	if len(s.Modes) == 0 {
+		_, err := fmt.Fprintln(w, "No games played yet.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODE\tPLAYED\tWON\tLOST\tWIN %\tSTREAK\tBEST\tFASTEST\tFEWEST\tAVG TIME\tPAR OR BETTER\tBEST SCORE\tPEEKED\tRESIGNED")
+	for _, k := range s.keys() {
+		r := s.Modes[k]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.1f\t%d\t%d\t%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			k, r.Played, r.Won, r.Lost(), r.WinRate(), r.CurrentStreak, r.LongestStreak,
+			formatSecs(r.FastestWinSecs), formatCount(r.FewestMoves), formatSecs(int(r.AverageWinTime()/time.Second)), r.ParOrBetter,
+			r.BestScore, r.PeekedWins, formatResigned(r))
+	}
+	return tw.Flush()
+}
+
+// summary is the JSON shape printed by WriteJSON, with derived figures included.
+type summary struct {
+	Played         int     `json:"played"`
+	Won            int     `json:"won"`
+	Lost           int     `json:"lost"`
+	WinPercent     float64 `json:"win_percent"`
+	CurrentStreak  int     `json:"current_streak"`
+	LongestStreak  int     `json:"longest_streak"`
+	FastestWinSecs int     `json:"fastest_win_secs"`
+	FewestMoves    int     `json:"fewest_moves"`
+	AverageWinSecs int     `json:"average_win_secs"`
+	ParOrBetter    int     `json:"par_or_better"`
+	BestScore      int     `json:"best_score"`
+	PeekedWins     int     `json:"peeked_wins"`
+	Resigned       int     `json:"resigned"`
+	AverageResign  float64 `json:"average_resigned_cards"`
+}
+
+// WriteJSON writes every mode as JSON, including derived figures.
+func (s *Stats) WriteJSON(w io.Writer) error {
+	out := make(map[string]summary, len(s.Modes))
+	for k, r := range s.Modes {
+		out[k] = summary{
+			Played:         r.Played,
+			Won:            r.Won,
+			Lost:           r.Lost(),
+			WinPercent:     r.WinRate(),
+			CurrentStreak:  r.CurrentStreak,
+			LongestStreak:  r.LongestStreak,
+			FastestWinSecs: r.FastestWinSecs,
+			FewestMoves:    r.FewestMoves,
+			AverageWinSecs: int(r.AverageWinTime() / time.Second),
+			ParOrBetter:    r.ParOrBetter,
+			BestScore:      r.BestScore,
+			PeekedWins:     r.PeekedWins,
+			Resigned:       r.Resigned,
+			AverageResign:  r.AverageResignedCards(),
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func formatSecs(secs int) string {
+	if secs == 0 {
+		return "-"
+	}
+	return (time.Duration(secs) * time.Second).String()
+}
+
+// formatResigned shows the resigned games with their mean foundation cards.
+func formatResigned(r *Record) string {
+	if r.Resigned == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d (%.1f cards)", r.Resigned, r.AverageResignedCards())
+}
+
+func formatCount(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// Background analysis budgets: a full solve, then a win chance estimate.
+const (
+	analysisSolveNodes = 100_000
+	winChanceSamples   = 16
+	winChanceNodes     = 2000
+)
+
+// analysisRun is one background analysis of a position. It runs on its own
+// clone of the game and reports back through updates, which the model reads
+// one message at a time with next. The run closes updates when it stops.
+type analysisRun struct {
+	key     uint64
+	cancel  context.CancelFunc
+	updates chan analysisMsg
+}
+
+// analysisMsg reports progress or results from a run. Fields that are not
+// set are unchanged since the previous message.
+type analysisMsg struct {
+	run    *analysisRun
+	nodes  int                     // Solver positions searched so far
+	solve  *solver.Result          // Set once the solve has finished
+	chance *analysis.Estimate      // Running win chance estimate
+	moves  []analysis.MoveEstimate // Win chance after each legal move, set with done
+	done   bool                    // Last message of the run
+}
+
+// analysisState is what the model knows about the current position.
+type analysisState struct {
+	nodes  int
+	solve  *solver.Result
+	chance *analysis.Estimate
+	moves  []analysis.MoveEstimate
+	done   bool
+}
+
+// positionKey identifies the board, so analysis can be matched to the
+// position it was made for.
+func positionKey(g *game.Game) uint64 {
+	s := g.State()
+	return s.Hash() ^ uint64(g.Recycles)
+}
+
+// restartAnalysis cancels the running analysis and starts one for the
+// current position, unless that position is already being analysed.
+func (m *model) restartAnalysis() tea.Cmd {
+	if m.analysisOff {
+		return nil
+	}
+	if m.solution.active() {
+		// The board is about to change on every tick
+		m.stopAnalysis()
+		return nil
+	}
+	key := positionKey(m.game)
+	if m.analysis != nil && m.analysis.key == key {
+		return nil
+	}
+	m.stopAnalysis()
+	m.verdict = analysisState{}
+	if m.game.IsWon {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &analysisRun{key: key, cancel: cancel, updates: make(chan analysisMsg, 8)}
+	m.analysis = run
+	go run.work(ctx, m.game.Clone())
+	return run.next()
+}
+
+// stopAnalysis cancels the running analysis, if any.
+func (m *model) stopAnalysis() {
+	if m.analysis != nil {
+		m.analysis.cancel()
+		m.analysis = nil
+	}
+}
+
+// next waits for the run's next message.
+func (r *analysisRun) next() tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-r.updates
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// work solves the position, then estimates its win chance and that of each
+// legal move, sending progress as it goes.
+func (r *analysisRun) work(ctx context.Context, g *game.Game) {
+	defer close(r.updates)
+
+	res := solver.Solve(ctx, g, solver.Options{
+		MaxNodes: analysisSolveNodes,
+		Progress: func(nodes int) { r.progress(analysisMsg{nodes: nodes}) },
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	r.progress(analysisMsg{nodes: res.Nodes, solve: &res})
+
+	est, moves := analysis.WinChance(ctx, g, analysis.EstimateOptions{
+		Samples:  winChanceSamples,
+		MaxNodes: winChanceNodes,
+		Seed:     int64(r.key),
+		PerMove:  true,
+		Progress: func(e analysis.Estimate) { r.progress(analysisMsg{chance: &e}) },
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	select {
+	case r.updates <- analysisMsg{run: r, chance: &est, moves: moves, done: true}:
+	case <-ctx.Done():
+	}
+}
+
+// progress sends a progress message without blocking, dropping it if the
+// model is behind. One slot is always left free for the final message.
+func (r *analysisRun) progress(msg analysisMsg) {
+	if len(r.updates) < cap(r.updates)-1 {
+		msg.run = r
+		r.updates <- msg
+	}
+}
+
+// handleAnalysis records a message from the current run and waits for the next.
+func (m *model) handleAnalysis(msg analysisMsg) tea.Cmd {
+	if msg.run != m.analysis {
+		return nil // From a cancelled run
+	}
+	m.verdict.nodes = max(m.verdict.nodes, msg.nodes)
+	if msg.solve != nil {
+		m.verdict.solve = msg.solve
+	}
+	if msg.chance != nil {
+		m.verdict.chance = msg.chance
+	}
+	if msg.done {
+		m.verdict.moves = msg.moves
+		m.verdict.done = true
+		return nil
+	}
+	return msg.run.next()
+}
+
+// analysisText describes the analysis of the position for the footer.
+func (m model) analysisText() string {
+	if m.analysisOff {
+		return "analysis off"
+	}
+	v := m.verdict
+	if v.solve == nil {
+		return fmt.Sprintf("analysing... %dk positions", v.nodes/1000)
+	}
+
+	var text string
+	switch v.solve.Status {
+	case solver.Solved:
+		text = fmt.Sprintf("winnable: yes (%d moves)", len(v.solve.Moves))
+	case solver.NoWinFound:
+		text = "no win found"
+	default:
+		text = "winnable: ?"
+	}
+
+	if v.chance != nil {
+		if p, ok := v.chance.Chance(); ok {
+			text += fmt.Sprintf(" · win chance %.0f%%", 100*p)
+			if !v.done {
+				text += fmt.Sprintf(" (%d/%d)", v.chance.Samples, winChanceSamples)
+			}
+		}
+	}
+	return text + m.moveChanceText()
+}
+
+// moveChanceText gives the win chance after each legal move from the selected
+// pile, or from the pile under the cursor when nothing is selected. Draws and
+// recycles count as moves from the stock.
+func (m model) moveChanceText() string {
+	pile := m.sourcePileIndex
+	if pile == -1 {
+		pile = m.game.ActivePile
+	}
+	var text string
+	for _, e := range m.verdict.moves {
+		from := e.Move.From
+		if e.Move.Kind != game.MoveTransfer {
+			from = game.StockPile
+		}
+		if from != pile {
+			continue
+		}
+		if p, ok := e.Chance(); ok {
+			text += fmt.Sprintf(" · %s %.0f%%", e.Move, 100*p)
+		}
+	}
+	return text
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// autoPlayStep is the delay before each card auto-play moves while
+// animations are on.
+const autoPlayStep = 150 * time.Millisecond
+
+// autoPlayMsg plays the next safe card of game, unless another game has been
+// dealt since.
+type autoPlayMsg struct {
+	game *game.Game
+}
+
+// autoPlay plays cards no other card could need up to the foundations after
+// the player moves, one at a time with animations on and all at once without.
+func (m *model) autoPlay() tea.Cmd {
+	if !m.autoPlayDue {
+		return nil
+	}
+	m.autoPlayDue = false
+	if m.config.Animations {
+		g := m.game
+		return tea.Tick(autoPlayStep, func(time.Time) tea.Msg { return autoPlayMsg{game: g} })
+	}
+	for m.playSafeCard() {
+	}
+	m.handleEvents()
+	m.autoPlayDue = false
+	return nil
+}
+
+// handleAutoPlay plays one safe card; the move's event schedules the next.
+func (m *model) handleAutoPlay(msg autoPlayMsg) {
+	if msg.game == m.game {
+		m.playSafeCard()
+	}
+}
+
+// playSafeCard plays a safe card to its foundation and reports whether there
+// was one. Nothing moves while the player holds a card or the solution plays.
+func (m *model) playSafeCard() bool {
+	if !m.config.AutoPlay || m.solution.active() || m.sourcePileIndex != -1 || m.game.IsWon {
+		return false
+	}
+	mv, ok := solver.SafeMove(m.game)
+	if !ok {
+		return false
+	}
+	return m.game.Apply(mv) == nil
+}
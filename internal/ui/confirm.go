@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"math/rand"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// confirmScreen holds an action that would throw away a game in progress
+// until the player agrees to it.
+type confirmScreen struct {
+	prompt string
+	run    func(m *model) tea.Cmd
+}
+
+// inProgress reports whether leaving the game would lose something: cards
+// have moved and the game is neither won nor resigned.
+func (m model) inProgress() bool {
+	return len(m.game.History) > 0 && !m.game.IsWon && m.resigned == nil
+}
+
+// confirm runs an action that replaces the game, asking first if one is in
+// progress.
+func (m *model) confirm(prompt string, run func(m *model) tea.Cmd) tea.Cmd {
+	if !m.inProgress() {
+		return run(m)
+	}
+	m.push(&confirmScreen{prompt: prompt, run: run})
+	return nil
+}
+
+func (s *confirmScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch key.String() {
+	case "y", "enter":
+		m.close(s)
+		return s.run(m)
+	case "n", "esc":
+		m.close(s)
+	}
+	return nil
+}
+
+// view renders the confirmation prompt
+func (s *confirmScreen) view(m *model) string {
+	return styles.HelpOverlay.Render("\n  " + s.prompt +
+		"\n  The game in progress will be abandoned.\n\n  y: yes  n/Esc: keep playing\n")
+}
+
+// newRandomGame deals a random game under the pending rules and goes to it.
+func newRandomGame(m *model) tea.Cmd {
+	m.deal(rand.Int63())
+	m.home()
+	return m.rateDeal()
+}
+
+// restartGame deals the current game again from the start and goes to it.
+func restartGame(m *model) tea.Cmd {
+	m.restart()
+	m.home()
+	return nil
+}
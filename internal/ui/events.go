@@ -0,0 +1,49 @@
+package ui
+
+import "github.com/solitaire-tui/solitaire-tui/internal/game"
+
+// eventQueue collects game events until the model handles them. It is shared
+// by pointer because the model itself is copied on every update.
+type eventQueue struct {
+	events []game.Event
+}
+
+func (q *eventQueue) push(e game.Event) {
+	q.events = append(q.events, e)
+}
+
+// drain returns the queued events and empties the queue.
+func (q *eventQueue) drain() []game.Event {
+	if q == nil {
+		return nil
+	}
+	events := q.events
+	q.events = nil
+	return events
+}
+
+// setGame starts playing g, subscribing to its events. The deal is unrated
+// until a rating arrives, and any solution playback stops.
+func (m *model) setGame(g *game.Game) {
+	m.game = g
+	m.rating = nil
+	m.stopSolution()
+	m.assisted = false
+	m.events = &eventQueue{}
+	g.Subscribe(m.events.push)
+}
+
+// handleEvents reacts to everything the game reported during the last update.
+func (m *model) handleEvents() {
+	for _, e := range m.events.drain() {
+		switch e.(type) {
+		case game.CardMoved, game.StockDrawn:
+			// The game counts as played once the first card moves
+			m.recordStart()
+			m.autoPlayDue = m.config.AutoPlay && !m.solution.active()
+		case game.GameWon:
+			m.recordWin()
+			m.saveRecord()
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import "github.com/solitaire-tui/solitaire-tui/internal/config"
+
+// keymaps translate the keys of a keymap to the board's own. The vim keymap
+// is the board's own, arrow keys included, and needs no translation.
+var keymaps = map[string]map[string]string{
+	config.KeymapEmacs: {
+		"ctrl+b": "left",
+		"ctrl+f": "right",
+		"ctrl+p": "up",
+		"ctrl+n": "down",
+		"ctrl+a": "gg",
+		"ctrl+e": "G",
+		"ctrl+g": "esc",
+	},
+}
+
+// boardKey returns the board key that key stands for in the chosen keymap.
+func (m model) boardKey(key string) string {
+	if k, ok := keymaps[m.config.Keymap][key]; ok {
+		return k
+	}
+	return key
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// menuItem is one entry of the main menu.
+type menuItem struct {
+	label  string
+	choose func(m *model) tea.Cmd
+}
+
+var menuItems = []menuItem{
+	{"Continue", func(m *model) tea.Cmd { m.home(); return nil }},
+	{"New game", func(m *model) tea.Cmd { m.push(&newGameScreen{}); return nil }},
+	{"Variants", func(m *model) tea.Cmd { m.push(&rulesScreen{}); return nil }},
+	{"Daily challenge", func(m *model) tea.Cmd { m.push(newCalendarScreen()); return nil }},
+	{"Statistics", func(m *model) tea.Cmd { m.push(&statsScreen{}); return nil }},
+	{"Settings", func(m *model) tea.Cmd { m.push(&settingsScreen{}); return nil }},
+	{"Replays", func(m *model) tea.Cmd { m.push(newReplaysScreen(m.recordsDir)); return nil }},
+	{"Quit", func(m *model) tea.Cmd { return tea.Quit }},
+}
+
+// menuScreen is the main menu.
+type menuScreen struct {
+	cursor int
+}
+
+// WithMenu opens the main menu over the board, as on a normal start.
+func (m model) WithMenu() model {
+	m.push(&menuScreen{})
+	return m
+}
+
+func (s *menuScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch key.String() {
+	case "esc", "m":
+		m.close(s)
+	case "q":
+		return tea.Quit
+	case "k", "up":
+		s.cursor = max(s.cursor-1, 0)
+	case "j", "down":
+		s.cursor = min(s.cursor+1, len(menuItems)-1)
+	case "enter", " ", "space":
+		return menuItems[s.cursor].choose(m)
+	}
+	return nil
+}
+
+func (s *menuScreen) view(m *model) string {
+	var b strings.Builder
+	b.WriteString("\n  ♠ SOLITAIRE ♥\n  ───────────\n\n")
+	for i, item := range menuItems {
+		cursor := "  "
+		label := item.label
+		if i == s.cursor {
+			cursor = "► "
+			label = lipgloss.NewStyle().Foreground(styles.SelectedBorder).Render(label)
+		}
+		b.WriteString("  " + cursor + label + "\n")
+	}
+	b.WriteString("\n  j/k: choose  Enter: open\n  Press m or Esc to return to the game\n")
+	return styles.HelpOverlay.Render(b.String())
+}
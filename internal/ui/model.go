@@ -1,16 +1,22 @@
 package ui
 
 import (
+	"context"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/config"
 	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+	"github.com/solitaire-tui/solitaire-tui/internal/stats"
 )
 
 // Messages
 type clearInvalidMoveMsg struct{}
 type clearLastKeyMsg struct{}
+type clearPeekMsg struct{}
 
 // Command timeout
 const commandTimeout = 300 * time.Millisecond
@@ -30,26 +36,108 @@ type model struct {
 	width  int
 	height int
 
+	// Events from the game, handled after each update
+	events *eventQueue
+
 	// Selection state for moving cards
 	sourcePileIndex int
 	sourceCardIndex int
 
 	// UI state
-	showInvalidMove bool
-	showHelp        bool
-	lastKey         string
-	lastKeyTime     time.Time
+	screens     []screen     // Screens open over the board, topmost last
+	invalidMove error        // Why the last move was rejected; nil hides the message
+	peek        *peekedCard  // Hidden card briefly revealed; nil when none
+	resigned    *resignation // Nil unless the player gave the game up
+	lastKey     string
+	lastKeyTime time.Time
+
+	// Statistics
+	stats     *stats.Stats
+	statsErr  error
+	startedAt time.Time // Zero until the first card moves
+
+	// Daily challenge
+	daily    time.Time // Date of the daily deal; zero for regular games
+	dailyLog *stats.DailyLog
+	dailyErr error
+
+	// Settings; changes are saved unless the file could not be loaded
+	config        *config.Config
+	configErr     error // From loading the settings file
+	configSaveErr error
+
+	// Finished games, saved for the replays screen; empty to keep none
+	recordsDir string
+	recordsErr error
+
+	// House rules for the next deal; the current game keeps its own
+	rules    game.Rules
+	practice bool // Deal open games, which are not counted in the statistics
+
+	// Difficulty of the current deal; nil until it has been rated
+	rating *analysis.Rating
+
+	// Background analysis of the current position
+	analysis    *analysisRun // Nil when nothing is running
+	verdict     analysisState
+	analysisOff bool // Set for boards that are only displayed
+
+	// Shortest solution of the current deal; nil until found
+	par       *solver.ParResult
+	parDeal   parDeal
+	parCancel context.CancelFunc
+
+	// Solution playback
+	solution solutionState
+	assisted bool // The solver played part of this game, so a win is not counted
+
+	autoPlayDue bool // A card moved, so safe cards may now go up
 }
 
 func NewModel() model {
-	return model{
-		game:            game.NewGame(),
+	m := model{
+		config:          config.Default(),
+		rules:           game.DefaultRules(),
 		sourcePileIndex: -1,
 		sourceCardIndex: -1,
 	}
+	m.setGame(game.NewGame())
+	return m
+}
+
+// WithDaily starts the daily challenge for the UTC date of day, under the
+// rules set so far.
+func (m model) WithDaily(day time.Time) model {
+	m.playDaily(day)
+	return m
+}
+
+// playDaily starts the daily challenge for the UTC date of day.
+func (m *model) playDaily(day time.Time) {
+	m.start(game.NewGameWithRules(game.DailySeed(day), m.rules))
+	m.daily = day.UTC()
+}
+
+// WithStats attaches persistent statistics to the model. err is the error, if
+// any, from loading them and is shown on the statistics screen.
+func (m model) WithStats(s *stats.Stats, err error) model {
+	m.stats = s
+	m.statsErr = err
+	return m
+}
+
+// WithDailyLog attaches the daily challenge log, which backs the calendar screen.
+// err is the error, if any, from loading it.
+func (m model) WithDailyLog(l *stats.DailyLog, err error) model {
+	m.dailyLog = l
+	m.dailyErr = err
+	return m
 }
 
 func (m model) Init() tea.Cmd {
+	if m.rating == nil {
+		return m.rateDeal()
+	}
 	return nil
 }
 
@@ -60,6 +148,12 @@ func clearInvalidMoveAfter(d time.Duration) tea.Cmd {
 	})
 }
 
+func clearPeekAfter(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return clearPeekMsg{}
+	})
+}
+
 func clearLastKeyAfter(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return clearLastKeyMsg{}
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// dealSearchTries bounds the deals rated while looking for a difficulty.
+const dealSearchTries = 200
+
+// ratingMsg carries the rating of the deal dealt from seed under rules.
+type ratingMsg struct {
+	seed   int64
+	rules  game.Rules
+	rating analysis.Rating
+}
+
+// dealFoundMsg carries a deal found for a requested difficulty.
+type dealFoundMsg struct {
+	seed   int64
+	rating analysis.Rating
+	err    error
+}
+
+// newGameOptions are the rows of the new game screen; Unrated stands for any deal.
+var newGameOptions = append([]analysis.Difficulty{analysis.Unrated}, analysis.Difficulties...)
+
+// rateDeal rates the current deal in the background.
+func (m model) rateDeal() tea.Cmd {
+	seed, rules := m.game.Seed, m.game.Rules
+	return func() tea.Msg {
+		g := game.NewGameWithRules(seed, rules)
+		return ratingMsg{seed: seed, rules: rules, rating: analysis.Rate(context.Background(), g, 0)}
+	}
+}
+
+// findDeal looks for a deal of difficulty d in the background.
+func findDeal(d analysis.Difficulty, rules game.Rules) tea.Cmd {
+	return func() tea.Msg {
+		seed, r, err := analysis.FindDeal(context.Background(), d, rules, rand.Int63(), dealSearchTries)
+		return dealFoundMsg{seed: seed, rating: r, err: err}
+	}
+}
+
+// WithDifficulty deals a game of difficulty d under the current rules. It
+// searches synchronously, so it is meant for start-up.
+func (m model) WithDifficulty(d analysis.Difficulty) (model, error) {
+	seed, r, err := analysis.FindDeal(context.Background(), d, m.rules, rand.Int63(), dealSearchTries)
+	if err != nil {
+		return m, err
+	}
+	m.setGame(m.newGame(seed))
+	m.rating = &r
+	return m, nil
+}
+
+// deal starts a fresh game from seed under the pending rules.
+func (m *model) deal(seed int64) {
+	m.start(m.newGame(seed))
+	m.daily = time.Time{}
+}
+
+// restart deals the same game again, from the start and under the same rules.
+func (m *model) restart() {
+	g := game.NewGameWithRules(m.game.Seed, m.game.Rules)
+	g.Open = m.game.Open
+	rating := m.rating
+	m.start(g)
+	m.rating = rating
+}
+
+// start plays g from the beginning, clearing everything left over from the
+// previous game.
+func (m *model) start(g *game.Game) {
+	m.setGame(g)
+	m.startedAt = time.Time{}
+	m.resigned = nil
+	m.invalidMove = nil
+	m.peek = nil
+	m.sourcePileIndex = -1
+	m.sourceCardIndex = -1
+	m.viewport.GotoTop()
+}
+
+// newGameScreen deals a new game, of a chosen difficulty if wanted.
+type newGameScreen struct {
+	cursor  int
+	dealing bool  // Searching for a deal of the chosen difficulty
+	err     error // Why the search failed
+}
+
+func (s *newGameScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case dealFoundMsg:
+		s.handleDealFound(m, msg)
+		return nil
+	case tea.KeyMsg:
+		return s.handleKey(m, msg.String())
+	}
+	return nil
+}
+
+func (s *newGameScreen) handleKey(m *model, key string) tea.Cmd {
+	if s.dealing {
+		if key == "esc" {
+			s.dealing = false
+			m.close(s)
+		}
+		return nil
+	}
+
+	switch key {
+	case "esc", "n":
+		m.close(s)
+	case "k", "up":
+		s.cursor = max(s.cursor-1, 0)
+	case "j", "down":
+		s.cursor = min(s.cursor+1, len(newGameOptions)-1)
+	case "o":
+		m.practice = !m.practice
+	case "enter", " ", "space":
+		d := newGameOptions[s.cursor]
+		if d == analysis.Unrated {
+			return m.confirm("Start a new game?", newRandomGame)
+		}
+		return m.confirm("Start a new "+d.String()+" game?", func(m *model) tea.Cmd {
+			s.dealing = true
+			s.err = nil
+			return findDeal(d, m.rules)
+		})
+	}
+	return nil
+}
+
+// handleDealFound starts the deal the background search found.
+func (s *newGameScreen) handleDealFound(m *model, msg dealFoundMsg) {
+	if !s.dealing {
+		return // Cancelled
+	}
+	s.dealing = false
+	if msg.err != nil {
+		s.err = msg.err
+		return
+	}
+	m.deal(msg.seed)
+	m.rating = &msg.rating
+	m.home()
+}
+
+// view renders the new game screen
+func (s *newGameScreen) view(m *model) string {
+	var b strings.Builder
+	b.WriteString("\n  ♠ NEW GAME ♥\n  ───────────\n\n")
+
+	for i, d := range newGameOptions {
+		label := "Any deal"
+		if d != analysis.Unrated {
+			label = sentence(d.String())
+		}
+		cursor := "  "
+		if i == s.cursor {
+			cursor = "► "
+			label = lipgloss.NewStyle().Foreground(styles.SelectedBorder).Render(label)
+		}
+		b.WriteString("  " + cursor + label + "\n")
+	}
+
+	practice := "off"
+	if m.practice {
+		practice = "on"
+	}
+	b.WriteString("\n  Practice (open deal, not counted): " + practice + "\n")
+
+	if s.dealing {
+		b.WriteString("\n  " + styles.HelpStyle.Render(fmt.Sprintf("Finding a %s deal...", newGameOptions[s.cursor])) + "\n")
+	}
+	if s.err != nil {
+		b.WriteString("\n" + styles.ErrorStyle.Render("⚠ "+s.err.Error()) + "\n")
+	}
+
+	b.WriteString("\n  j/k: choose  o: practice  Enter: deal\n  Press n or Esc to close\n")
+	return styles.HelpOverlay.Render(b.String())
+}
+
+// ratingText describes the deal's difficulty for the header.
+func (m model) ratingText() string {
+	if m.rating == nil {
+		return "rating..."
+	}
+	return m.rating.Difficulty.String()
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/config"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// parNodes is the node budget for finding a deal's par.
+const parNodes = 300_000
+
+// parDeal identifies the deal a par belongs to.
+type parDeal struct {
+	seed  int64
+	rules game.Rules
+}
+
+// parMsg carries the shortest solution found for a deal.
+type parMsg struct {
+	deal   parDeal
+	result solver.ParResult
+}
+
+// restartPar looks for the shortest solution of a new deal in the
+// background, cancelling the search for the previous one.
+func (m *model) restartPar() tea.Cmd {
+	if m.analysisOff {
+		return nil
+	}
+	deal := parDeal{seed: m.game.Seed, rules: m.game.Rules}
+	if m.parDeal == deal {
+		return nil
+	}
+	if m.parCancel != nil {
+		m.parCancel()
+	}
+	m.parDeal = deal
+	m.par = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.parCancel = cancel
+	return func() tea.Msg {
+		g := game.NewGameWithRules(deal.seed, deal.rules)
+		opts := solver.ParOptions{Options: solver.Options{MaxNodes: parNodes}}
+		return parMsg{deal: deal, result: solver.Par(ctx, g, opts)}
+	}
+}
+
+// stopPar cancels an unfinished par search. The deal is forgotten so the
+// search starts again once analysis is back on.
+func (m *model) stopPar() {
+	if m.parCancel != nil {
+		m.parCancel()
+		m.parCancel = nil
+		m.parDeal = parDeal{}
+	}
+}
+
+// handlePar keeps the par if it is for the deal being played.
+func (m *model) handlePar(msg parMsg) {
+	if msg.deal != m.parDeal {
+		return
+	}
+	m.par = &msg.result
+	m.parCancel = nil
+}
+
+// parMoves returns the par of the current deal, or false if it is not known.
+func (m model) parMoves() (int, bool) {
+	if m.par == nil || m.par.Status != solver.Solved {
+		return 0, false
+	}
+	return len(m.par.Moves), true
+}
+
+// movesText shows the move count against par, then the score under the
+// chosen scoring, for the footer. A par the search could not prove shortest is marked as approximate.
+func (m model) movesText() string {
+	text := fmt.Sprintf("Moves %d", len(m.game.History))
+	switch {
+	case m.par == nil && m.analysisOff:
+	case m.par == nil:
+		text += " · par …"
+	case m.par.Status != solver.Solved:
+		text += " · par -"
+	case !m.par.Proven:
+		text += fmt.Sprintf(" · par ~%d", len(m.par.Moves))
+	default:
+		text += fmt.Sprintf(" · par %d", len(m.par.Moves))
+	}
+
+	switch m.config.Scoring {
+	case config.ScoringStandard:
+		text += fmt.Sprintf(" · Score %d", m.game.Score)
+	case config.ScoringVegas:
+		text += fmt.Sprintf(" · Vegas $%d", m.game.VegasScore())
+	}
+	if m.game.Peeks > 0 {
+		text += fmt.Sprintf(" (%d peeks)", m.game.Peeks)
+	}
+	return text + " "
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// peekDuration is how long a peeked card stays revealed.
+const peekDuration = 2 * time.Second
+
+// peekedCard is a hidden card currently revealed by a peek.
+type peekedCard struct {
+	pile  int
+	index int
+	card  game.Card
+}
+
+// handlePeek reveals the hidden card under the cursor, or the next stock card
+// when the stock is selected, for peekDuration.
+func (m *model) handlePeek() tea.Cmd {
+	c, err := m.game.Peek(m.game.ActivePile, m.game.ActiveCard)
+	if err != nil {
+		m.invalidMove = err
+		return clearInvalidMoveAfter(2 * time.Second)
+	}
+	index := m.game.ActiveCard
+	if m.game.ActivePile == game.StockPile {
+		index = len(m.game.Stock.Cards) - 1
+	}
+	m.peek = &peekedCard{pile: m.game.ActivePile, index: index, card: c}
+	return clearPeekAfter(peekDuration)
+}
+
+// peeking reports whether c, at cardIdx in pile, is the card being peeked at.
+// The stock shows its next card whatever index it is drawn with.
+func (m model) peeking(c *game.Card, pileIdx, cardIdx int) bool {
+	if m.peek == nil || m.peek.pile != pileIdx || m.peek.card != *c {
+		return false
+	}
+	return pileIdx == game.StockPile || m.peek.index == cardIdx
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// newGame deals seed under the pending rules, open when practising.
+func (m model) newGame(seed int64) *game.Game {
+	if m.practice {
+		return game.NewOpenGame(seed, m.rules)
+	}
+	return game.NewGameWithRules(seed, m.rules)
+}
+
+// WithPractice switches practice mode, in which every deal is open, on or
+// off and redeals the current seed that way. Practice games are not counted
+// in the statistics.
+func (m model) WithPractice(on bool) model {
+	m.practice = on
+	m.setGame(m.newGame(m.game.Seed))
+	return m
+}
+
+// renderStockList lists the stock of an open game in the order it will be
+// drawn, one group per draw.
+func (m model) renderStockList() string {
+	cards := m.game.Stock.Cards
+	if len(cards) == 0 {
+		return styles.HelpStyle.Render("Stock: empty")
+	}
+
+	draw := max(m.game.Rules.DrawCount, 1)
+	var b strings.Builder
+	b.WriteString(styles.HelpStyle.Render("Stock:"))
+	for i := range cards {
+		// The stock is drawn from the end
+		c := cards[len(cards)-1-i]
+		if i > 0 && i%draw == 0 && draw > 1 {
+			b.WriteString(styles.HelpStyle.Render(" │"))
+		}
+		style := styles.OpenBlackSuit
+		if c.Suit.Color() == "Red" {
+			style = styles.OpenRedSuit
+		}
+		b.WriteString(" " + style.Render(c.Rank.String()+c.Suit.String()))
+	}
+	return b.String()
+}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// replaySpeeds are the delays between steps while a replay is playing.
+var replaySpeeds = []time.Duration{
+	2 * time.Second,
+	time.Second,
+	500 * time.Millisecond,
+	250 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+// replayTickMsg advances a playing replay. gen ties the tick to the play
+// session that scheduled it so stale ticks are ignored after pause/resume.
+type replayTickMsg struct{ gen int }
+
+// replayModel is a read-only viewer that steps through a recorded game.
+// The board is drawn by an embedded game model so replays look exactly like live play.
+type replayModel struct {
+	board model
+
+	seed  int64
+	rules game.Rules
+	open  bool
+	moves []game.Move
+	step  int // Number of moves applied to the board
+
+	playing bool
+	speed   int // Index into replaySpeeds
+	gen     int
+
+	// Mistake analysis of lost games
+	findingMistake bool
+	mistakeCtx     context.Context
+	cancelMistake  context.CancelFunc
+	mistake        *analysis.Mistake
+	mistakeErr     error
+}
+
+// mistakeNodes is the solver budget per position when looking for a mistake.
+const mistakeNodes = 200_000
+
+// mistakeMsg carries the result of the mistake analysis.
+type mistakeMsg struct {
+	mistake analysis.Mistake
+	err     error
+}
+
+// NewReplayModel creates a viewer for the moves recorded in g, starting at the
+// deal. If g was lost or resigned, the viewer looks for the move that likely lost it
+// and jumps there once found.
+func NewReplayModel(g *game.Game) replayModel {
+	board := NewModel()
+	board.analysisOff = true
+	r := replayModel{
+		board: board,
+		seed:  g.Seed,
+		rules: g.Rules,
+		open:  g.Open,
+		moves: g.History,
+		speed: 1,
+	}
+	if g.Lost {
+		r.findingMistake = true
+		r.mistakeCtx, r.cancelMistake = context.WithCancel(context.Background())
+	}
+	r.seek(0)
+	return r
+}
+
+func (r replayModel) Init() tea.Cmd {
+	if !r.findingMistake {
+		return nil
+	}
+	ctx, seed, rules, moves := r.mistakeCtx, r.seed, r.rules, r.moves
+	return func() tea.Msg {
+		m, err := analysis.FindMistake(ctx, seed, rules, moves, mistakeNodes)
+		return mistakeMsg{mistake: m, err: err}
+	}
+}
+
+// stop cancels the mistake analysis, if it is still running.
+func (r replayModel) stop() {
+	if r.cancelMistake != nil {
+		r.cancelMistake()
+	}
+}
+
+func (r replayModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			r.stop()
+			return r, tea.Quit
+		case "l", "right":
+			r.playing = false
+			r.seek(r.step + 1)
+		case "h", "left":
+			r.playing = false
+			r.seek(r.step - 1)
+		case "g", "home":
+			r.playing = false
+			r.seek(0)
+		case "G", "end":
+			r.playing = false
+			r.seek(len(r.moves))
+		case " ", "space", "p":
+			r.playing = !r.playing
+			if r.playing {
+				if r.step == len(r.moves) {
+					r.seek(0)
+				}
+				r.gen++
+				return r, r.tick()
+			}
+		case "+", "=":
+			r.speed = min(r.speed+1, len(replaySpeeds)-1)
+		case "-":
+			r.speed = max(r.speed-1, 0)
+		case "m":
+			if r.mistake != nil {
+				r.playing = false
+				r.showMistake()
+			}
+		}
+		return r, nil
+
+	case mistakeMsg:
+		r.findingMistake = false
+		if msg.err != nil {
+			r.mistakeErr = msg.err
+			return r, nil
+		}
+		r.mistake = &msg.mistake
+		r.playing = false
+		r.showMistake()
+		return r, nil
+
+	case replayTickMsg:
+		if !r.playing || msg.gen != r.gen {
+			return r, nil
+		}
+		r.seek(r.step + 1)
+		if r.step == len(r.moves) {
+			r.playing = false
+			return r, nil
+		}
+		return r, r.tick()
+
+	case tea.WindowSizeMsg, tea.MouseMsg:
+		// Only layout and scrolling reach the board; its keys would change the game.
+		board, cmd := r.board.Update(msg)
+		r.board = board.(model)
+		return r, cmd
+	}
+	return r, nil
+}
+
+func (r replayModel) tick() tea.Cmd {
+	gen := r.gen
+	return tea.Tick(replaySpeeds[r.speed], func(time.Time) tea.Msg {
+		return replayTickMsg{gen: gen}
+	})
+}
+
+// showMistake shows the position before the blunder, with the blunder's card
+// marked as the source and the winning alternative's card under the cursor.
+func (r *replayModel) showMistake() {
+	r.seek(r.mistake.Index)
+	g := r.board.game
+	r.board.sourcePileIndex, r.board.sourceCardIndex = movedCard(g, r.mistake.Move)
+	g.ClearSelection()
+	if len(r.mistake.Alternative) > 0 {
+		g.SetSelection(movedCard(g, r.mistake.Alternative[0]))
+	}
+}
+
+// movedCard returns the pile and index of the card m picks up: the first
+// moved card for transfers, the top of the stock for draws and the top of the
+// waste for recycles.
+func movedCard(g *game.Game, m game.Move) (pile, index int) {
+	switch m.Kind {
+	case game.MoveDraw:
+		pile = game.StockPile
+	case game.MoveRecycle:
+		pile = game.WastePile
+	default:
+		return m.From, m.Index
+	}
+	return pile, len(g.GetPile(pile).Cards) - 1
+}
+
+// seek rebuilds the board as it stood after n moves and highlights the last move.
+func (r *replayModel) seek(n int) {
+	n = max(0, min(n, len(r.moves)))
+	g := game.NewGameWithRules(r.seed, r.rules)
+	g.Open = r.open
+	for _, mv := range r.moves[:max(n-1, 0)] {
+		g.Apply(mv)
+	}
+
+	r.board.sourcePileIndex = -1
+	r.board.sourceCardIndex = -1
+	g.ClearSelection()
+
+	if n > 0 {
+		last := r.moves[n-1]
+		from, to := game.StockPile, game.WastePile
+		moved := 1
+		switch last.Kind {
+		case game.MoveRecycle:
+			from, to = game.WastePile, game.StockPile
+		case game.MoveTransfer:
+			from, to = last.From, last.To
+			moved = len(g.GetPile(from).Cards) - last.Index
+		}
+		g.Apply(last)
+
+		// Source: whatever is left on top of the pile the cards came from.
+		r.board.sourcePileIndex = from
+		r.board.sourceCardIndex = len(g.GetPile(from).Cards) - 1
+		// Destination: the first card that arrived.
+		g.SetSelection(to, len(g.GetPile(to).Cards)-moved)
+	}
+
+	g.CheckWinCondition()
+	r.board.game = g
+	r.step = n
+}
+
+func (r replayModel) View() string {
+	if !r.board.ready {
+		return "\n  Initializing replay..."
+	}
+
+	r.board.viewport.SetContent(r.board.renderGameContent())
+	return fmt.Sprintf("%s\n%s\n%s", r.board.headerView(), r.board.viewport.View(), r.footerView())
+}
+
+// footerView renders the replay transport status.
+func (r replayModel) footerView() string {
+	var status strings.Builder
+
+	state := "⏸ Paused"
+	if r.playing {
+		state = "▶ Playing"
+	}
+	status.WriteString(lipgloss.NewStyle().Foreground(styles.SourceBorder).Render(fmt.Sprintf("%s %d/%d ", state, r.step, len(r.moves))))
+
+	switch {
+	case r.mistake != nil && r.step == r.mistake.Index:
+		status.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("✗ Move %d likely lost the game: %s ", r.step+1, r.mistake.Move)))
+		if len(r.mistake.Alternative) > 0 {
+			status.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("✓ %s wins instead ", r.mistake.Alternative[0])))
+		}
+		if r.mistake.Uncertain {
+			status.WriteString(styles.HelpStyle.Render("(the loss may be later) "))
+		}
+	case r.step > 0:
+		status.WriteString(lipgloss.NewStyle().Foreground(styles.SelectedBorder).Render(r.moves[r.step-1].String() + " "))
+	}
+
+	help := "h/l:step space:play +/-:speed g/G:start/end q:quit"
+	switch {
+	case r.findingMistake:
+		status.WriteString(styles.HelpStyle.Render("│ finding the likely losing move... "))
+	case r.mistake != nil:
+		help = "m:mistake " + help
+	case r.mistakeErr != nil:
+		status.WriteString(styles.HelpStyle.Render("│ " + sentence(r.mistakeErr.Error()) + " "))
+	}
+	status.WriteString(styles.HelpStyle.Render(fmt.Sprintf("│ speed %s │ %s", replaySpeeds[r.speed], help)))
+
+	return lipgloss.NewStyle().
+		Background(styles.TitleBackground).
+		Foreground(styles.HelpTextColor).
+		Width(r.board.width).
+		Render(status.String())
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/records"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// replaysShown is the number of saved games listed at once.
+const replaysShown = 12
+
+// WithRecords saves every finished game to dir, where the replays screen
+// finds them. err is the error, if any, from locating dir.
+func (m model) WithRecords(dir string, err error) model {
+	m.recordsDir = dir
+	m.recordsErr = err
+	return m
+}
+
+// saveRecord saves the current game for the replays screen.
+func (m *model) saveRecord() {
+	if m.recordsDir == "" {
+		return
+	}
+	_, m.recordsErr = records.Save(m.recordsDir, m.game, time.Now())
+}
+
+// replaysScreen lists the saved games, newest first.
+type replaysScreen struct {
+	entries []records.Entry
+	cursor  int
+	err     error
+}
+
+func newReplaysScreen(dir string) *replaysScreen {
+	s := &replaysScreen{}
+	if dir != "" {
+		s.entries, s.err = records.List(dir)
+	}
+	return s
+}
+
+func (s *replaysScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch key.String() {
+	case "esc", "q":
+		m.close(s)
+	case "k", "up":
+		s.cursor = max(s.cursor-1, 0)
+	case "j", "down":
+		s.cursor = min(s.cursor+1, len(s.entries)-1)
+	case "enter", " ", "space":
+		if len(s.entries) == 0 {
+			return nil
+		}
+		g, err := records.Load(s.entries[s.cursor].Path)
+		if err != nil {
+			s.err = err
+			return nil
+		}
+		r := &replayScreen{r: NewReplayModel(g)}
+		r.r.board.config = m.config // Cards the size the player chose
+		m.push(r)
+		return tea.Batch(r.r.Init(), r.update(m, tea.WindowSizeMsg{Width: m.width, Height: m.height}))
+	}
+	return nil
+}
+
+func (s *replaysScreen) view(m *model) string {
+	var b strings.Builder
+	b.WriteString("\n  SAVED GAMES\n  ───────────\n\n")
+	if len(s.entries) == 0 {
+		b.WriteString("  No games saved yet. Finished games appear here.\n")
+	}
+
+	// Keep the cursor in a window of replaysShown entries
+	first := max(0, min(s.cursor-replaysShown/2, len(s.entries)-replaysShown))
+	for i := first; i < min(first+replaysShown, len(s.entries)); i++ {
+		e := s.entries[i]
+		result := styles.ErrorStyle.Render("lost")
+		if e.Won {
+			result = styles.SuccessStyle.Render("won ")
+		}
+		line := fmt.Sprintf("%s  #%-20d %3d moves", e.Saved.Local().Format("2006-01-02 15:04"), e.Seed, e.Moves)
+		cursor := "  "
+		if i == s.cursor {
+			cursor = "► "
+			line = lipgloss.NewStyle().Foreground(styles.SelectedBorder).Render(line)
+		}
+		b.WriteString("  " + cursor + line + "  " + result + "\n")
+	}
+
+	for _, err := range []error{s.err, m.recordsErr} {
+		if err != nil {
+			b.WriteString("\n" + styles.ErrorStyle.Render("⚠ "+err.Error()) + "\n")
+		}
+	}
+	b.WriteString("\n  j/k: choose  Enter: replay\n  Press Esc to go back\n")
+	return styles.HelpOverlay.Render(b.String())
+}
+
+// replayScreen plays back a saved game in place of the board.
+type replayScreen struct {
+	r replayModel
+}
+
+func (s *replayScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "q", "esc":
+			s.r.stop()
+			m.close(s)
+			return nil
+		}
+	}
+	r, cmd := s.r.Update(msg)
+	s.r = r.(replayModel)
+	return cmd
+}
+
+func (s *replayScreen) view(m *model) string {
+	return s.r.View()
+}
+
+func (s *replayScreen) fullScreen() {}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// resignNodes is the solver budget for judging a resigned deal.
+const resignNodes = 300_000
+
+// resignation is a game the player has given up.
+type resignation struct {
+	seed    int64
+	cards   int            // Cards on the foundations when the game was given up
+	verdict *solver.Result // Whether the deal could have been won; nil while solving
+}
+
+// resignVerdictMsg carries the solver's verdict on a resigned deal.
+type resignVerdictMsg struct {
+	seed   int64
+	result solver.Result
+}
+
+// resignScreen first confirms giving up the game, then shows the verdict and
+// stays until the player picks what to play next.
+type resignScreen struct{}
+
+func (s *resignScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	if m.resigned == nil {
+		switch key.String() {
+		case "y", "enter":
+			return m.resign()
+		case "n", "esc":
+			m.close(s)
+		}
+		return nil
+	}
+
+	switch key.String() {
+	case "q":
+		return tea.Quit
+	case "r":
+		return restartGame(m)
+	case "n", "enter":
+		return newRandomGame(m)
+	}
+	return nil
+}
+
+// resign gives up the game, recording it as lost, and asks the solver
+// whether the deal could have been won.
+func (m *model) resign() tea.Cmd {
+	m.stopSolution()
+	m.game.ClearSelection()
+	m.sourcePileIndex = -1
+	m.sourceCardIndex = -1
+	cards := 0
+	for _, f := range m.game.Foundations {
+		cards += len(f.Cards)
+	}
+	m.recordStart()
+	m.recordResign(cards)
+	m.game.Resign()
+	m.saveRecord()
+	m.resigned = &resignation{seed: m.game.Seed, cards: cards}
+
+	// The par search may already have settled it
+	if m.par != nil && m.par.Status != solver.Unknown {
+		m.resigned.verdict = &m.par.Result
+		return nil
+	}
+	seed, rules := m.game.Seed, m.game.Rules
+	return func() tea.Msg {
+		g := game.NewGameWithRules(seed, rules)
+		return resignVerdictMsg{seed: seed, result: solver.Solve(context.Background(), g, solver.Options{MaxNodes: resignNodes})}
+	}
+}
+
+// handleResignVerdict shows the verdict on the resigned deal.
+func (m *model) handleResignVerdict(msg resignVerdictMsg) {
+	if m.resigned != nil && m.resigned.seed == msg.seed {
+		m.resigned.verdict = &msg.result
+	}
+}
+
+// recordResign counts the game as lost with cards on the foundations.
+// Practice games are not counted.
+func (m *model) recordResign(cards int) {
+	if m.game.Open {
+		return
+	}
+	if !m.daily.IsZero() {
+		if m.dailyLog != nil {
+			m.dailyLog.Resign(m.daily, cards)
+			m.dailyErr = m.dailyLog.Save()
+		}
+		return
+	}
+	if m.stats != nil {
+		m.stats.Resign(m.modeKey(), cards)
+		m.statsErr = m.stats.Save()
+	}
+}
+
+// view renders the resign screen
+func (s *resignScreen) view(m *model) string {
+	var b strings.Builder
+	b.WriteString("\n  ♠ RESIGN ♥\n  ────────\n\n")
+
+	if m.resigned == nil {
+		b.WriteString("  Give up this game? It counts as a loss.\n")
+		b.WriteString("\n  y: resign  n/Esc: keep playing\n")
+		return styles.HelpOverlay.Render(b.String())
+	}
+
+	r := m.resigned
+	fmt.Fprintf(&b, "  Game resigned with %d of 52 cards on the foundations.\n\n", r.cards)
+	verdict := "Checking whether the deal could be won..."
+	if r.verdict != nil {
+		switch r.verdict.Status {
+		case solver.Solved:
+			verdict = fmt.Sprintf("The deal was winnable, in %d moves.", len(r.verdict.Moves))
+		case solver.NoWinFound:
+			verdict = "The solver found no win from the deal."
+		default:
+			verdict = "The solver could not decide whether the deal was winnable."
+		}
+	}
+	b.WriteString("  " + lipgloss.NewStyle().Foreground(styles.SourceBorder).Render(verdict) + "\n")
+	b.WriteString("\n  r: retry same deal  n/Enter: new deal\n")
+	return styles.HelpOverlay.Render(b.String())
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// ruleOption is one editable row on the rules screen.
+type ruleOption struct {
+	label  string
+	value  func(r game.Rules) string
+	change func(r *game.Rules, dir int) // dir is -1 or +1
+}
+
+// maxRedeals is the largest finite redeal count offered before "unlimited".
+const maxRedeals = 3
+
+var ruleOptions = []ruleOption{
+	{
+		label: "Draw count",
+		value: func(r game.Rules) string { return strconv.Itoa(r.DrawCount) },
+		change: func(r *game.Rules, dir int) {
+			if r.DrawCount == 1 {
+				r.DrawCount = 3
+			} else {
+				r.DrawCount = 1
+			}
+		},
+	},
+	{
+		label: "Redeals",
+		value: func(r game.Rules) string { return redealsText(r.Redeals) },
+		change: func(r *game.Rules, dir int) {
+			// Cycle 0, 1, ..., maxRedeals, unlimited
+			n := r.Redeals
+			if n == game.UnlimitedRedeals {
+				n = maxRedeals + 1
+			}
+			n = (n + dir + maxRedeals + 2) % (maxRedeals + 2)
+			if n == maxRedeals+1 {
+				n = game.UnlimitedRedeals
+			}
+			r.Redeals = n
+		},
+	},
+	{
+		label: "Empty column",
+		value: func(r game.Rules) string {
+			if r.AnyCardOnEmpty {
+				return "any card"
+			}
+			return "Kings only"
+		},
+		change: func(r *game.Rules, dir int) { r.AnyCardOnEmpty = !r.AnyCardOnEmpty },
+	},
+	{
+		label:  "Foundation to tableau",
+		value:  func(r game.Rules) string { return onOff(r.FoundationToTableau) },
+		change: func(r *game.Rules, dir int) { r.FoundationToTableau = !r.FoundationToTableau },
+	},
+	{
+		label:  "Move partial stacks",
+		value:  func(r game.Rules) string { return onOff(r.PartialStacks) },
+		change: func(r *game.Rules, dir int) { r.PartialStacks = !r.PartialStacks },
+	},
+	{
+		label:  "Play stock to tableau",
+		value:  func(r game.Rules) string { return onOff(r.StockToTableau) },
+		change: func(r *game.Rules, dir int) { r.StockToTableau = !r.StockToTableau },
+	},
+}
+
+// redealsText gives a redeal count the way the settings file writes it.
+func redealsText(n int) string {
+	if n == game.UnlimitedRedeals {
+		return "unlimited"
+	}
+	return strconv.Itoa(n)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "allowed"
+	}
+	return "not allowed"
+}
+
+// WithRules sets the house rules and deals a fresh game under them.
+func (m model) WithRules(r game.Rules) model {
+	m.rules = r
+	m.setGame(m.newGame(m.game.Seed))
+	return m
+}
+
+// WithSeed deals the game from seed under the current rules.
+func (m model) WithSeed(seed int64) model {
+	m.setGame(m.newGame(seed))
+	return m
+}
+
+// rulesScreen edits the house rules, the variant of Klondike being played.
+type rulesScreen struct {
+	cursor int
+}
+
+func (s *rulesScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch key.String() {
+	case "esc", "r":
+		m.close(s)
+	case "k", "up":
+		s.cursor = max(s.cursor-1, 0)
+	case "j", "down":
+		s.cursor = min(s.cursor+1, len(ruleOptions)-1)
+	case "h", "left":
+		before := m.rules
+		ruleOptions[s.cursor].change(&m.rules, -1)
+		m.saveDrawRules(before)
+	case "l", "right", "enter", " ", "space":
+		before := m.rules
+		ruleOptions[s.cursor].change(&m.rules, 1)
+		m.saveDrawRules(before)
+	case "d":
+		return m.confirm("Start a new game with these rules?", newRandomGame)
+	}
+	return m.applyRules()
+}
+
+// saveDrawRules writes the draw count or redeal count back to the settings,
+// which hold them too, if it changed from before. The other house rules are
+// not saved.
+func (m *model) saveDrawRules(before game.Rules) {
+	switch {
+	case m.rules.DrawCount != before.DrawCount:
+		m.config.Draw = m.rules.DrawCount
+	case m.rules.Redeals != before.Redeals:
+		m.config.Redeals = redealsText(m.rules.Redeals)
+	default:
+		return
+	}
+	m.saveConfig()
+}
+
+// applyRules puts changed house rules into effect. Rules can only change
+// before the first card moves; otherwise they wait for the next deal.
+func (m *model) applyRules() tea.Cmd {
+	if len(m.game.History) == 0 && m.game.Rules != m.rules {
+		m.game.Rules = m.rules
+		m.rating = nil
+		return m.rateDeal()
+	}
+	return nil
+}
+
+// view renders the house rules screen
+func (s *rulesScreen) view(m *model) string {
+	var b strings.Builder
+	b.WriteString("\n  ♠ HOUSE RULES ♥\n  ───────────────\n\n")
+
+	for i, opt := range ruleOptions {
+		cursor := "  "
+		line := fmt.Sprintf("%-24s %s", opt.label, opt.value(m.rules))
+		if i == s.cursor {
+			cursor = "► "
+			line = lipgloss.NewStyle().Foreground(styles.SelectedBorder).Render(line)
+		}
+		b.WriteString("  " + cursor + line + "\n")
+	}
+
+	if m.rules != m.game.Rules {
+		b.WriteString("\n  " + styles.HelpStyle.Render("Changes apply from the next game") + "\n")
+	}
+
+	b.WriteString("\n  j/k: choose  h/l: change  d: deal with these rules\n  Press r or Esc to close\n")
+	return styles.HelpOverlay.Render(b.String())
+}
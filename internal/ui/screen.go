@@ -0,0 +1,50 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// screen is a page shown over the board: a menu, an overlay or a viewer.
+// Screens are stacked; the one on top gets every key and message, and going
+// back closes it to uncover the one beneath, down to the board itself.
+type screen interface {
+	// update handles a message while the screen is on top.
+	update(m *model, msg tea.Msg) tea.Cmd
+	// view renders the screen. It is drawn on the felt in place of the board.
+	view(m *model) string
+}
+
+// fullScreen is a screen that draws the whole terminal, header and footer
+// included, rather than a page on the felt.
+type fullScreen interface {
+	screen
+	fullScreen()
+}
+
+// push opens s on top of the current screen.
+func (m *model) push(s screen) {
+	m.screens = append(m.screens[:len(m.screens):len(m.screens)], s)
+}
+
+// close goes back from s, closing it and every screen opened over it.
+func (m *model) close(s screen) {
+	for i, open := range m.screens {
+		if open == s {
+			m.screens = m.screens[:i:i]
+			return
+		}
+	}
+}
+
+// home closes every screen, back to the board.
+func (m *model) home() {
+	m.screens = nil
+}
+
+// top returns the screen on top, or nil when the board is showing.
+func (m model) top() screen {
+	if len(m.screens) == 0 {
+		return nil
+	}
+	return m.screens[len(m.screens)-1]
+}
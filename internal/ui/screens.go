@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// helpScreen lists the keys.
+type helpScreen struct{}
+
+func (s *helpScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "?", "esc":
+			m.close(s)
+		}
+	}
+	return nil
+}
+
+func (s *helpScreen) view(m *model) string {
+	return m.renderHelpOverlay()
+}
+
+// statsScreen shows the statistics of every mode.
+type statsScreen struct{}
+
+func (s *statsScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "s", "esc":
+			m.close(s)
+		}
+	}
+	return nil
+}
+
+func (s *statsScreen) view(m *model) string {
+	return m.renderStatsOverlay()
+}
+
+// calendarScreen shows a month of daily challenge results and starts
+// today's challenge.
+type calendarScreen struct {
+	month time.Time
+}
+
+func newCalendarScreen() *calendarScreen {
+	return &calendarScreen{month: startOfMonth(time.Now())}
+}
+
+func (s *calendarScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch key.String() {
+	case "c", "esc":
+		m.close(s)
+	case "h", "left":
+		s.month = s.month.AddDate(0, -1, 0)
+	case "l", "right":
+		s.month = s.month.AddDate(0, 1, 0)
+	case "enter":
+		return m.confirm("Play today's daily challenge?", playToday)
+	}
+	return nil
+}
+
+func (s *calendarScreen) view(m *model) string {
+	return m.renderCalendarOverlay(s.month)
+}
+
+// playToday starts today's daily challenge.
+func playToday(m *model) tea.Cmd {
+	m.playDaily(time.Now())
+	m.home()
+	return m.rateDeal()
+}
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/config"
+	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
+)
+
+// WithConfig applies the player's settings and deals the current seed again
+// under them. err is the error, if any, from loading the settings file; it
+// is shown on a screen of its own, and c should then be the defaults.
+func (m model) WithConfig(c *config.Config, err error) model {
+	m.config = c
+	m.configErr = err
+	m.rules.DrawCount, m.rules.Redeals = c.Rules().DrawCount, c.Rules().Redeals
+	m.practice = c.Practice
+	m.analysisOff = !c.Analysis
+	styles.SetTheme(c.Theme)
+	m.setGame(m.newGame(m.game.Seed))
+	if err != nil {
+		m.push(&configErrorScreen{err: err})
+	}
+	return m
+}
+
+// saveConfig writes the settings back to their file.
+func (m *model) saveConfig() {
+	m.configSaveErr = m.config.Save()
+}
+
+// setting is one line of the settings screen.
+type setting struct {
+	label  string
+	value  func(m *model) string
+	change func(m *model, dir int) // dir is +1 or -1, the direction to cycle
+}
+
+var settings = []setting{
+	{
+		label: "Draw count",
+		value: func(m *model) string { return strconv.Itoa(m.config.Draw) },
+		change: func(m *model, dir int) {
+			m.config.Draw = 4 - m.config.Draw // 1 and 3
+			m.rules.DrawCount = m.config.Draw
+		},
+	},
+	{
+		label: "Redeals",
+		value: func(m *model) string { return m.config.Redeals },
+		change: func(m *model, dir int) {
+			m.config.Redeals = cycle(config.Redeals, m.config.Redeals, dir)
+			m.rules.Redeals = m.config.Rules().Redeals
+		},
+	},
+	{
+		label:  "Scoring",
+		value:  func(m *model) string { return m.config.Scoring },
+		change: func(m *model, dir int) { m.config.Scoring = cycle(config.Scorings, m.config.Scoring, dir) },
+	},
+	{
+		label:  "Auto-play safe cards",
+		value:  func(m *model) string { return switchText(m.config.AutoPlay) },
+		change: func(m *model, dir int) { m.config.AutoPlay = !m.config.AutoPlay },
+	},
+	{
+		label: "Theme",
+		value: func(m *model) string { return m.config.Theme },
+		change: func(m *model, dir int) {
+			m.config.Theme = cycle(config.Themes, m.config.Theme, dir)
+			styles.SetTheme(m.config.Theme)
+		},
+	},
+	{
+		label:  "Card size",
+		value:  func(m *model) string { return m.config.CardSize },
+		change: func(m *model, dir int) { m.config.CardSize = cycle(config.CardSizes, m.config.CardSize, dir) },
+	},
+	{
+		label:  "Keymap",
+		value:  func(m *model) string { return m.config.Keymap },
+		change: func(m *model, dir int) { m.config.Keymap = cycle(config.Keymaps, m.config.Keymap, dir) },
+	},
+	{
+		label:  "Animations",
+		value:  func(m *model) string { return switchText(m.config.Animations) },
+		change: func(m *model, dir int) { m.config.Animations = !m.config.Animations },
+	},
+	{
+		label: "Practice mode (open deals, not counted)",
+		value: func(m *model) string { return switchText(m.practice) },
+		change: func(m *model, dir int) {
+			m.practice = !m.practice
+			m.config.Practice = m.practice
+		},
+	},
+	{
+		label: "Background analysis",
+		value: func(m *model) string { return switchText(!m.analysisOff) },
+		change: func(m *model, dir int) {
+			m.analysisOff = !m.analysisOff
+			m.config.Analysis = !m.analysisOff
+			if m.analysisOff {
+				m.stopAnalysis()
+				m.stopPar()
+				m.verdict = analysisState{}
+			}
+		},
+	},
+}
+
+// cycle returns the value next to cur in values in direction dir, wrapping around.
+func cycle(values []string, cur string, dir int) string {
+	i := slices.Index(values, cur)
+	return values[(i+dir+len(values))%len(values)]
+}
+
+func switchText(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// settingsScreen changes the player's settings and saves them as they change.
+type settingsScreen struct {
+	cursor int
+}
+
+func (s *settingsScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch key.String() {
+	case "esc", "q":
+		m.close(s)
+		return nil
+	case "k", "up":
+		s.cursor = max(s.cursor-1, 0)
+		return nil
+	case "j", "down":
+		s.cursor = min(s.cursor+1, len(settings)-1)
+		return nil
+	case "enter", " ", "space", "l", "right":
+		settings[s.cursor].change(m, 1)
+	case "h", "left":
+		settings[s.cursor].change(m, -1)
+	default:
+		return nil
+	}
+	m.saveConfig()
+	return m.applyRules()
+}
+
+func (s *settingsScreen) view(m *model) string {
+	var b strings.Builder
+	b.WriteString("\n  SETTINGS\n  ────────\n\n")
+	for i, set := range settings {
+		cursor := "  "
+		line := fmt.Sprintf("%-40s %s", set.label, set.value(m))
+		if i == s.cursor {
+			cursor = "► "
+			line = lipgloss.NewStyle().Foreground(styles.SelectedBorder).Render(line)
+		}
+		b.WriteString("  " + cursor + line + "\n")
+	}
+	if m.rules != m.game.Rules {
+		b.WriteString("\n  " + styles.HelpStyle.Render("Draw count and redeals apply from the next deal.") + "\n")
+	}
+	switch {
+	case m.configErr != nil:
+		b.WriteString("\n" + styles.ErrorStyle.Render("⚠ Settings are not saved: the settings file could not be used") + "\n")
+	case m.configSaveErr != nil:
+		b.WriteString("\n" + styles.ErrorStyle.Render("⚠ "+m.configSaveErr.Error()) + "\n")
+	}
+	b.WriteString("\n  j/k: choose  h/l or Enter: change\n  Press Esc to go back\n")
+	return styles.HelpOverlay.Render(b.String())
+}
+
+// configErrorScreen explains that the settings file could not be used.
+type configErrorScreen struct {
+	err error
+}
+
+func (s *configErrorScreen) update(m *model, msg tea.Msg) tea.Cmd {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "enter", "esc":
+			m.close(s)
+		case "q":
+			return tea.Quit
+		}
+	}
+	return nil
+}
+
+func (s *configErrorScreen) view(m *model) string {
+	var b strings.Builder
+	b.WriteString("\n  " + styles.ErrorStyle.Render("⚠ SETTINGS FILE") + "\n  ───────────────\n\n")
+	b.WriteString("  The settings file could not be used:\n\n")
+	b.WriteString("  " + s.err.Error() + "\n\n")
+	b.WriteString("  The game runs with the default settings. Until the file is\n")
+	b.WriteString("  fixed or removed, changed settings are not saved over it.\n")
+	b.WriteString("\n  Press Enter to continue, q to quit\n")
+	return styles.HelpOverlay.Render(b.String())
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// Solution playback settings.
+const (
+	solutionNodes = 500_000
+	solutionStep  = 400 * time.Millisecond
+)
+
+var errNoSolution = errors.New("no solution found from here")
+
+// solutionMsg carries the solver's answer to a solution request.
+type solutionMsg struct {
+	gen    int
+	result solver.Result
+}
+
+// solutionTickMsg plays the next move of the solution. gen ties it to the
+// playback that scheduled it.
+type solutionTickMsg struct{ gen int }
+
+// solutionState is a solution being fetched or played back.
+type solutionState struct {
+	gen     int
+	solving bool
+	cancel  context.CancelFunc // Stops the solver while solving
+	moves   []game.Move        // Moves still to play
+	played  int
+}
+
+// active reports whether a solution is being fetched or played.
+func (s solutionState) active() bool {
+	return s.solving || len(s.moves) > 0
+}
+
+// startSolution asks for a solution from the current position. The background
+// analysis may already have one; otherwise the solver runs in the background.
+func (m *model) startSolution() tea.Cmd {
+	m.stopSolution()
+	if m.game.IsWon {
+		return nil
+	}
+	m.sourcePileIndex = -1
+	m.sourceCardIndex = -1
+
+	if v := m.verdict.solve; v != nil && v.Status == solver.Solved {
+		return m.playSolution(v.Moves)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.solution.solving = true
+	m.solution.cancel = cancel
+	gen, g := m.solution.gen, m.game.Clone()
+	return func() tea.Msg {
+		return solutionMsg{gen: gen, result: solver.Solve(ctx, g, solver.Options{MaxNodes: solutionNodes})}
+	}
+}
+
+// stopSolution hands control back to the player.
+func (m *model) stopSolution() {
+	if m.solution.cancel != nil {
+		m.solution.cancel()
+	}
+	m.solution = solutionState{gen: m.solution.gen + 1}
+}
+
+// handleSolution starts playing the solver's answer.
+func (m *model) handleSolution(msg solutionMsg) tea.Cmd {
+	if msg.gen != m.solution.gen || !m.solution.solving {
+		return nil
+	}
+	m.solution.solving = false
+	if msg.result.Status != solver.Solved {
+		m.invalidMove = errNoSolution
+		return clearInvalidMoveAfter(2 * time.Second)
+	}
+	return m.playSolution(msg.result.Moves)
+}
+
+// playSolution starts playing moves back, one per tick. A game finished this
+// way is not counted as a win.
+func (m *model) playSolution(moves []game.Move) tea.Cmd {
+	m.solution.moves = append([]game.Move(nil), moves...)
+	m.assisted = true
+	return m.solutionTick()
+}
+
+func (m model) solutionTick() tea.Cmd {
+	gen := m.solution.gen
+	return tea.Tick(solutionStep, func(time.Time) tea.Msg {
+		return solutionTickMsg{gen: gen}
+	})
+}
+
+// stepSolution plays the next move of the solution and moves the cursor to
+// the card that moved.
+func (m *model) stepSolution(msg solutionTickMsg) tea.Cmd {
+	if msg.gen != m.solution.gen || len(m.solution.moves) == 0 {
+		return nil
+	}
+	mv := m.solution.moves[0]
+	moved := 1
+	if mv.Kind == game.MoveTransfer {
+		moved = len(m.game.GetPile(mv.From).Cards) - mv.Index
+	}
+	if err := m.game.Apply(mv); err != nil {
+		m.stopSolution()
+		m.invalidMove = err
+		return clearInvalidMoveAfter(2 * time.Second)
+	}
+	m.solution.moves = m.solution.moves[1:]
+	m.solution.played++
+
+	pile := mv.To
+	switch mv.Kind {
+	case game.MoveDraw:
+		pile = game.WastePile
+	case game.MoveRecycle:
+		pile = game.StockPile
+	}
+	m.game.SetSelection(pile, max(len(m.game.GetPile(pile).Cards)-moved, 0))
+
+	if len(m.solution.moves) == 0 {
+		return nil
+	}
+	return m.solutionTick()
+}
+
+// solutionText describes the playback for the footer.
+func (m model) solutionText() string {
+	if m.solution.solving {
+		return "solving... "
+	}
+	total := m.solution.played + len(m.solution.moves)
+	return fmt.Sprintf("▶ solution %d/%d (any key takes over) ", m.solution.played, total)
+}
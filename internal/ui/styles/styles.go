@@ -1,6 +1,10 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 const (
 	CardWidth     = 11 // Width for realistic card appearance (support art)
@@ -53,7 +57,8 @@ W|M
 var (
 	// App background - green felt table
 	// App background - premium deep felt
-	AppBackground = lipgloss.Color("#052d05") // More premium deep green
+	AppBackground       = lipgloss.Color("#052d05") // More premium deep green
+	EmptyPileBackground = lipgloss.Color("#0a3d0e") // Slightly lighter than table
 
 	// Suit colors - bright for visibility
 	RedSuitColor   = lipgloss.Color("#FF0000") // Bright red for Hearts/Diamonds
@@ -80,28 +85,129 @@ var (
 	OverlayBg       = lipgloss.Color("#1e1e1e")
 )
 
+// Theme is the set of colours that changes from one theme to another.
+type Theme struct {
+	AppBackground       lipgloss.Color
+	EmptyPileBackground lipgloss.Color
+	TitleBackground     lipgloss.Color
+	FaceDownBackground  lipgloss.Color
+	FaceDownForeground  lipgloss.Color
+	SelectedBorder      lipgloss.Color
+	SourceBorder        lipgloss.Color
+	OverlayBg           lipgloss.Color
+}
+
+// themes are the themes by name. The felt theme is the palette above.
+var themes = map[string]Theme{
+	"felt": {
+		AppBackground:       AppBackground,
+		EmptyPileBackground: EmptyPileBackground,
+		TitleBackground:     TitleBackground,
+		FaceDownBackground:  FaceDownBackground,
+		FaceDownForeground:  FaceDownForeground,
+		SelectedBorder:      SelectedBorder,
+		SourceBorder:        SourceBorder,
+		OverlayBg:           OverlayBg,
+	},
+	"midnight": {
+		AppBackground:       lipgloss.Color("#0d1b2a"),
+		EmptyPileBackground: lipgloss.Color("#1b263b"),
+		TitleBackground:     lipgloss.Color("#283c63"),
+		FaceDownBackground:  lipgloss.Color("#6a040f"),
+		FaceDownForeground:  lipgloss.Color("#e85d04"),
+		SelectedBorder:      lipgloss.Color("#90e0ef"),
+		SourceBorder:        lipgloss.Color("#ffd166"),
+		OverlayBg:           lipgloss.Color("#111827"),
+	},
+	"contrast": {
+		AppBackground:       lipgloss.Color("#000000"),
+		EmptyPileBackground: lipgloss.Color("#1a1a1a"),
+		TitleBackground:     lipgloss.Color("#333333"),
+		FaceDownBackground:  lipgloss.Color("#000080"),
+		FaceDownForeground:  lipgloss.Color("#FFFFFF"),
+		SelectedBorder:      lipgloss.Color("#00FF00"),
+		SourceBorder:        lipgloss.Color("#FFFF00"),
+		OverlayBg:           lipgloss.Color("#000000"),
+	},
+}
+
+// SetTheme switches every colour and style to the named theme.
+func SetTheme(name string) error {
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+	AppBackground = t.AppBackground
+	EmptyPileBackground = t.EmptyPileBackground
+	TitleBackground = t.TitleBackground
+	FaceDownBackground = t.FaceDownBackground
+	FaceDownForeground = t.FaceDownForeground
+	SelectedBorder = t.SelectedBorder
+	SourceBorder = t.SourceBorder
+	OverlayBg = t.OverlayBg
+	build()
+	return nil
+}
+
+// Styles, built from the colours of the current theme
 var (
+	AppStyle          lipgloss.Style
+	TitleStyle        lipgloss.Style
+	BadgeStyle        lipgloss.Style
+	BaseCard          lipgloss.Style
+	RedSuit           lipgloss.Style
+	BlackSuit         lipgloss.Style
+	FaceDownCard      lipgloss.Style
+	OpenRedSuit       lipgloss.Style
+	OpenBlackSuit     lipgloss.Style
+	EmptyPile         lipgloss.Style
+	SelectedCard      lipgloss.Style
+	SelectedRedCard   lipgloss.Style
+	SelectedBlackCard lipgloss.Style
+	SourceCard        lipgloss.Style
+	SourceRedCard     lipgloss.Style
+	SourceBlackCard   lipgloss.Style
+	ErrorStyle        lipgloss.Style
+	SuccessStyle      lipgloss.Style
+	HelpStyle         lipgloss.Style
+	HelpOverlay       lipgloss.Style
+	StatusStyle       func(...string) string
+)
+
+func init() {
+	build()
+}
+
+// build makes the styles from the palette.
+func build() {
 	// General app style with green felt background
 	AppStyle = lipgloss.NewStyle().
-			Padding(1, 2).
-			Background(AppBackground)
+		Padding(1, 2).
+		Background(AppBackground)
 
 	// Title
 	TitleStyle = lipgloss.NewStyle().
-			Foreground(TitleForeground).
-			Background(TitleBackground).
-			Padding(0, 1).
-			Bold(true)
+		Foreground(TitleForeground).
+		Background(TitleBackground).
+		Padding(0, 1).
+		Bold(true)
+
+	// Badge shown next to the title, e.g. for the daily challenge
+	BadgeStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#000000")).
+		Background(SourceBorder).
+		Padding(0, 1).
+		Bold(true)
 
 	// Status text
 	StatusStyle = lipgloss.NewStyle().
-			Foreground(HelpTextColor).
-			Render
+		Foreground(HelpTextColor).
+		Render
 
 	// Base card style - realistic dimensions (borders now in content)
 	BaseCard = lipgloss.NewStyle().
-			Background(CardBackground).
-			Foreground(CardForeground)
+		Background(CardBackground).
+		Foreground(CardForeground)
 
 	// Red suit card (Hearts, Diamonds) - red on white
 	RedSuit = BaseCard.
@@ -109,17 +215,23 @@ var (
 
 	// Black suit card (Spades, Clubs) - black on white
 	BlackSuit = BaseCard.
-			Foreground(BlackSuitColor)
+		Foreground(BlackSuitColor)
 
 	// Face-down card with pattern (borders now in content)
 	FaceDownCard = lipgloss.NewStyle().
-			Background(FaceDownBackground).
-			Foreground(FaceDownForeground)
+		Background(FaceDownBackground).
+		Foreground(FaceDownForeground)
+
+	// Face-down cards of an open game show their face on the card back
+	OpenRedSuit = FaceDownCard.
+		Foreground(lipgloss.Color("#FF8A80"))
+	OpenBlackSuit = FaceDownCard.
+		Foreground(lipgloss.Color("#E8EAF6"))
 
 	// Empty pile placeholder (borders now in content)
 	EmptyPile = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#444444")).
-			Background(lipgloss.Color("#0a3d0e")) // Slightly lighter than table
+		Foreground(lipgloss.Color("#444444")).
+		Background(EmptyPileBackground)
 
 	// Selected card generic (cyan color indicator)
 	SelectedCard = BaseCard
@@ -141,22 +253,22 @@ var (
 
 	// Error text style
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ErrorColor).
-			Bold(true)
+		Foreground(ErrorColor).
+		Bold(true)
 
 	// Success text style
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(SuccessColor).
-			Bold(true)
+		Foreground(SuccessColor).
+		Bold(true)
 
 	// Help text style
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(HelpTextColor)
+		Foreground(HelpTextColor)
 
 	// Help overlay style
 	HelpOverlay = lipgloss.NewStyle().
-			Background(OverlayBg).
-			Padding(1, 2).
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#555555"))
-)
+		Background(OverlayBg).
+		Padding(1, 2).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#555555"))
+}
@@ -6,9 +6,16 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/stats"
 )
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	m.handleEvents()
+	return m, tea.Batch(cmd, m.autoPlay(), m.restartAnalysis(), m.restartPar())
+}
+
+func (m model) update(msg tea.Msg) (model, tea.Cmd) {
 	var (
 		cmd  tea.Cmd
 		cmds []tea.Cmd
@@ -18,21 +25,52 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		key := msg.String()
 
-		// Global keys
-		switch key {
-		case "q", "ctrl+c":
+		if key == "ctrl+c" {
 			return m, tea.Quit
-		case "?":
-			m.showHelp = !m.showHelp
-			return m, nil
 		}
 
-		// If help is open, only allow closing
-		if m.showHelp {
-			if key == "esc" {
-				m.showHelp = false
+		// The screen on top gets every key
+		if s := m.top(); s != nil {
+			return m, s.update(&m, msg)
+		}
+
+		key = m.boardKey(key)
+
+		// Any key takes over from solution playback
+		if m.solution.active() {
+			m.stopSolution()
+			if key == "a" || key == "esc" {
+				return m, nil
 			}
+		}
+
+		// Keys that open screens
+		switch key {
+		case "q":
+			return m, tea.Quit
+		case "m":
+			m.push(&menuScreen{})
+			return m, nil
+		case "?":
+			m.push(&helpScreen{})
 			return m, nil
+		case "s":
+			m.push(&statsScreen{})
+			return m, nil
+		case "c":
+			m.push(newCalendarScreen())
+			return m, nil
+		case "r":
+			m.push(&rulesScreen{})
+			return m, nil
+		case "n":
+			m.push(&newGameScreen{})
+			return m, nil
+		case "x":
+			if !m.game.IsWon {
+				m.push(&resignScreen{})
+				return m, nil
+			}
 		}
 
 		// Game interaction - handling multi-key commands and navigation
@@ -49,9 +87,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "dd":
 			// Draw command
-			m.handleDraw()
+			cmd = m.handleDraw()
 			m.lastKey = ""
-			return m, nil
+			return m, cmd
 		}
 
 		// Check for multi-key start
@@ -63,13 +101,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if m.lastKey == key {
 				// Double key pressed (gg or dd) - handled above ideally, but let's handle here if missed
 				if key == "d" {
-					m.handleDraw()
+					cmd = m.handleDraw()
 				} else if key == "g" {
 					m.game.SetSelection(game.StockPile, 0)
 					m.scrollToTop()
 				}
 				m.lastKey = ""
-				return m, nil
+				return m, cmd
 			}
 		}
 
@@ -86,6 +124,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter", "space":
 			m, cmd = m.handleSelectOrMove()
 			return m, cmd
+		case "a":
+			return m, m.startSolution()
+		case "p":
+			return m, m.handlePeek()
+		case "R":
+			return m, m.confirm("Restart this deal from the beginning?", restartGame)
 		case "esc":
 			m.game.ClearSelection()
 			m.sourcePileIndex = -1
@@ -112,16 +156,49 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case clearInvalidMoveMsg:
-		m.showInvalidMove = false
+		m.invalidMove = nil
+
+	case clearPeekMsg:
+		m.peek = nil
+
+	case ratingMsg:
+		// Ratings for a deal that has since been replaced are dropped
+		if msg.seed == m.game.Seed && msg.rules == m.game.Rules {
+			m.rating = &msg.rating
+		}
+
+	case analysisMsg:
+		cmds = append(cmds, m.handleAnalysis(msg))
+
+	case resignVerdictMsg:
+		m.handleResignVerdict(msg)
+
+	case parMsg:
+		m.handlePar(msg)
+
+	case solutionMsg:
+		cmds = append(cmds, m.handleSolution(msg))
+
+	case autoPlayMsg:
+		m.handleAutoPlay(msg)
+
+	case solutionTickMsg:
+		cmds = append(cmds, m.stepSolution(msg))
 
 	case clearLastKeyMsg:
 		// Execute single key action if timeout
 		if m.lastKey == "d" {
-			m.handleDraw()
+			cmds = append(cmds, m.handleDraw())
 		}
 		m.lastKey = ""
 	}
 
+	// Screens get every other message too, such as the results of their
+	// background work
+	if s := m.top(); s != nil {
+		cmds = append(cmds, s.update(&m, msg))
+	}
+
 	// Update viewport (handles mouse wheel, etc.)
 	m.viewport, cmd = m.viewport.Update(msg)
 	cmds = append(cmds, cmd)
@@ -130,16 +207,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 // handleDraw logic refactored for clarity and bug fixing
-func (m *model) handleDraw() {
+func (m *model) handleDraw() tea.Cmd {
 	if len(m.game.Stock.Cards) > 0 {
 		m.game.DrawCard()
 		// BUG FIX: Explicitly move selection to Waste pile
 		m.game.SetSelection(game.WastePile, len(m.game.Waste.Cards)-1)
 	} else {
-		m.game.RecycleWaste()
+		if err := m.game.Apply(game.Move{Kind: game.MoveRecycle}); err != nil && err != game.ErrWasteEmpty {
+			m.invalidMove = err
+			return clearInvalidMoveAfter(2 * time.Second)
+		}
 		// Keep selection on stock
 		m.game.SetSelection(game.StockPile, 0)
 	}
+	return nil
 }
 
 // Helper to move selection and potentially scroll
@@ -214,44 +295,88 @@ func (m model) handleSelectOrMove() (model, tea.Cmd) {
 		cardIdx := m.game.ActiveCard
 
 		// Validate selection
-		isValid := false
-		if pileIdx == game.StockPile || pileIdx == game.WastePile || (pileIdx >= game.FoundationPile1 && pileIdx <= game.FoundationPile4) {
-			isValid = true
-		} else if pileIdx >= game.TableauPile1 && pileIdx <= game.TableauPile7 {
+		var invalid error
+		if pileIdx >= game.TableauPile1 && pileIdx <= game.TableauPile7 {
 			// For tableau, card must be face up
 			pile := m.game.GetPile(pileIdx)
-			if pile != nil && cardIdx >= 0 && cardIdx < len(pile.Cards) {
-				if pile.Cards[cardIdx].FaceUp {
-					isValid = true
-				}
+			if cardIdx < 0 || cardIdx >= len(pile.Cards) {
+				invalid = game.ErrInvalidCard
+			} else if !pile.Cards[cardIdx].FaceUp {
+				invalid = game.ErrFaceDown
 			}
+		} else if pileIdx < game.StockPile || pileIdx > game.FoundationPile4 {
+			invalid = game.ErrInvalidPile
 		}
 
-		if isValid {
+		if invalid == nil {
 			m.sourcePileIndex = pileIdx
 			m.sourceCardIndex = cardIdx
 		} else {
-			m.showInvalidMove = true
+			m.invalidMove = invalid
 			return m, clearInvalidMoveAfter(2 * time.Second)
 		}
 	} else {
 		// Try to move to current target
 		targetPile := m.game.ActivePile
 		if targetPile != -1 {
-			success := m.game.Move(m.sourcePileIndex, m.sourceCardIndex, targetPile)
-			if success {
+			err := m.game.TryMove(m.sourcePileIndex, m.sourceCardIndex, targetPile)
+			if err == nil {
 				m.sourcePileIndex = -1
 				m.sourceCardIndex = -1
-
-				// Check victory
-				if m.game.HasWon() {
-					m.game.IsWon = true
-				}
 			} else {
-				m.showInvalidMove = true
+				m.invalidMove = err
 				return m, clearInvalidMoveAfter(2 * time.Second)
 			}
 		}
 	}
 	return m, nil
 }
+
+// modeKey returns the statistics key for the game being played.
+func (m model) modeKey() string {
+	return stats.ModeKey(game.VariantKlondike, m.game.Rules.DrawCount)
+}
+
+// recordStart counts the game as played the first time a card moves. Open
+// games are practice and not counted.
+func (m *model) recordStart() {
+	if !m.startedAt.IsZero() || m.game.Open {
+		return
+	}
+	m.startedAt = time.Now()
+	if !m.daily.IsZero() {
+		// Daily results go to the daily log, not the regular statistics.
+		if m.dailyLog != nil {
+			m.dailyLog.Attempt(m.daily)
+			m.dailyErr = m.dailyLog.Save()
+		}
+		return
+	}
+	if m.stats != nil {
+		m.stats.Begin(m.modeKey())
+		m.statsErr = m.stats.Save()
+	}
+}
+
+// recordWin records the win once the game flips to won. Games the solver
+// finished and open games are left out.
+func (m *model) recordWin() {
+	if m.assisted || m.game.Open {
+		return
+	}
+	if !m.daily.IsZero() {
+		if m.dailyLog != nil {
+			m.dailyLog.Solve(m.daily, time.Since(m.startedAt), len(m.game.History))
+			m.dailyErr = m.dailyLog.Save()
+		}
+		return
+	}
+	if m.stats != nil {
+		m.stats.Win(m.modeKey(), time.Since(m.startedAt), len(m.game.History))
+		m.stats.WinScore(m.modeKey(), m.game.Score, m.game.Peeks)
+		if par, ok := m.parMoves(); ok {
+			m.stats.WinAgainstPar(m.modeKey(), len(m.game.History), par)
+		}
+		m.statsErr = m.stats.Save()
+	}
+}
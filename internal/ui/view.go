@@ -3,9 +3,12 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/solitaire-tui/solitaire-tui/internal/config"
 	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/stats"
 	"github.com/solitaire-tui/solitaire-tui/internal/ui/styles"
 )
 
@@ -15,8 +18,12 @@ func (m model) View() string {
 		return "\n  Initializing Solitaire..."
 	}
 
-	if m.showHelp {
-		return styles.AppStyle.Render(m.renderHelpOverlay())
+	switch s := m.top().(type) {
+	case nil:
+	case fullScreen:
+		return s.view(&m)
+	default:
+		return styles.AppStyle.Render(s.view(&m))
 	}
 
 	// Calculate content for the viewport
@@ -46,7 +53,11 @@ func (m model) renderGameContent() string {
 	// Add some padding at top
 	b.WriteString("\n")
 	b.WriteString(m.renderTopRow())
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if m.game.Open {
+		b.WriteString(m.renderStockList())
+	}
+	b.WriteString("\n")
 
 	// Tableaus
 	b.WriteString(m.renderTableaus())
@@ -64,17 +75,25 @@ func (m model) renderGameContent() string {
 // headerView renders the title bar
 func (m model) headerView() string {
 	title := styles.TitleStyle.Render("♠ Solitaire TUI ♥")
-	line := strings.Repeat("─", max(0, m.width-lipgloss.Width(title)))
-	return lipgloss.JoinHorizontal(lipgloss.Center, title,
-		lipgloss.NewStyle().Background(styles.TitleBackground).Foreground(styles.TitleForeground).Render(line))
+	if !m.daily.IsZero() {
+		title = lipgloss.JoinHorizontal(lipgloss.Center, title,
+			styles.BadgeStyle.Render("Daily "+m.daily.Format(time.DateOnly)))
+	}
+	if m.game.Open {
+		title = lipgloss.JoinHorizontal(lipgloss.Center, title, styles.BadgeStyle.Render("Practice · open deal"))
+	}
+	bar := lipgloss.NewStyle().Background(styles.TitleBackground).Foreground(styles.TitleForeground)
+	seed := bar.Render(fmt.Sprintf(" Seed %d · %s ", m.game.Seed, m.ratingText()))
+	line := strings.Repeat("─", max(0, m.width-lipgloss.Width(title)-lipgloss.Width(seed)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, title, bar.Render(line), seed)
 }
 
 // footerView renders the status bar
 func (m model) footerView() string {
 	var status strings.Builder
 
-	if m.showInvalidMove {
-		status.WriteString(styles.ErrorStyle.Render("✗ Invalid move "))
+	if m.invalidMove != nil {
+		status.WriteString(styles.ErrorStyle.Render("✗ " + sentence(m.invalidMove.Error()) + " "))
 	}
 
 	if m.sourcePileIndex != -1 {
@@ -87,7 +106,15 @@ func (m model) footerView() string {
 		status.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#88FF88")).Render(fmt.Sprintf("► %s ", pileNames[m.game.ActivePile])))
 	}
 
-	status.WriteString(styles.HelpStyle.Render("│ hjkl:move Enter:select d:draw ?:help q:quit"))
+	status.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#88FF88")).Render(m.movesText()))
+
+	if m.solution.active() {
+		status.WriteString(lipgloss.NewStyle().Foreground(styles.SourceBorder).Render(m.solutionText()))
+	} else if !m.game.IsWon {
+		status.WriteString(lipgloss.NewStyle().Foreground(styles.SourceBorder).Render(m.analysisText() + " "))
+	}
+
+	status.WriteString(styles.HelpStyle.Render("│ hjkl:move Enter:select d:draw p:peek a:solve n:new x:resign m:menu ?:help q:quit"))
 
 	// Ensure background covers full width
 	bar := lipgloss.NewStyle().
@@ -123,18 +150,11 @@ func (m model) renderTopRow() string {
 			borderVert = styles.BorderVert
 		}
 
-		// Build 7-line empty pile with box borders
+		// Build the empty pile with box borders
 		line2 := borderVert + "         " + borderVert
 		// Center line with text (e.g., "  ○  " or "  ♠  ")
 		line4 := borderVert + "    " + centerText + "    " + borderVert
-		content := borderTop + "\n" +
-			line2 + "\n" +
-			line2 + "\n" +
-			line4 + "\n" +
-			line2 + "\n" +
-			line2 + "\n" +
-			borderBottom
-		return style.Render(content)
+		return style.Render(m.frame(borderTop, borderBottom, line2, line2, line4, line2, line2))
 	}
 
 	// Helper to render a specific pile's top card or empty slot
@@ -158,7 +178,18 @@ func (m model) renderTopRow() string {
 	stockActive := m.game.ActivePile == game.StockPile
 	stockSource := m.sourcePileIndex == game.StockPile
 	var stockStr string
-	if len(m.game.Stock.Cards) > 0 {
+	if len(m.game.Stock.Cards) > 0 && (m.game.Open || m.peeking(m.game.Stock.Peek(), game.StockPile, 0)) {
+		// The next card to be drawn is on show in an open game or while it is
+		// peeked at. The stock is selected as a
+		// whole, whatever card index the selection holds.
+		idx := len(m.game.Stock.Cards) - 1
+		if stockSource {
+			idx = m.sourceCardIndex
+		} else if stockActive {
+			idx = m.game.ActiveCard
+		}
+		stockStr = m.renderCard(m.game.Stock.Peek(), game.StockPile, idx, false)
+	} else if len(m.game.Stock.Cards) > 0 {
 		style := styles.FaceDownCard
 
 		// Select border based on state and apply color
@@ -182,14 +213,8 @@ func (m model) renderTopRow() string {
 		}
 
 		// Stock with box borders and ░ fill
-		content := borderTop + "\n" +
-			borderVert + styles.FaceDownFill + borderVert + "\n" +
-			borderVert + styles.FaceDownFill + borderVert + "\n" +
-			borderVert + styles.FaceDownFill + borderVert + "\n" +
-			borderVert + styles.FaceDownFill + borderVert + "\n" +
-			borderVert + styles.FaceDownFill + borderVert + "\n" +
-			borderBottom
-		stockStr = style.Render(content)
+		fill := borderVert + styles.FaceDownFill + borderVert
+		stockStr = style.Render(m.frame(borderTop, borderBottom, fill, fill, fill, fill, fill))
 	} else {
 		stockStr = renderEmptyPile("○", stockActive, stockSource)
 	}
@@ -249,14 +274,7 @@ func (m model) renderTableaus() string {
 
 			line2 := borderVert + "         " + borderVert
 			line4 := borderVert + "    K    " + borderVert // K centered for King placement
-			content := borderTop + "\n" +
-				line2 + "\n" +
-				line2 + "\n" +
-				line4 + "\n" +
-				line2 + "\n" +
-				line2 + "\n" +
-				borderBottom
-			colBuilder.WriteString(style.Render(content))
+			colBuilder.WriteString(style.Render(m.frame(borderTop, borderBottom, line2, line2, line4, line2, line2)))
 		} else {
 			// Stack of cards
 			for i, card := range pile.Cards {
@@ -294,7 +312,7 @@ func (m model) renderTableaus() string {
 }
 
 // renderCard creates the string for a single card using Unicode Box Drawing characters
-// Dimensions: 11 chars wide × 7 lines high
+// Dimensions: 11 chars wide × 7 lines high, or 5 when compact
 func (m model) renderCard(c *game.Card, pileIdx, cardIdx int, isOverlap bool) string {
 	isActive := m.game.ActivePile == pileIdx && m.game.ActiveCard == cardIdx
 	isSource := m.sourcePileIndex == pileIdx && m.sourceCardIndex == cardIdx
@@ -329,7 +347,7 @@ func (m model) renderCard(c *game.Card, pileIdx, cardIdx int, isOverlap bool) st
 	borderL := borderStyle.Render(borderVertStr)
 	borderR := borderStyle.Render(borderVertStr)
 
-	if !c.FaceUp {
+	if !c.FaceUp && !m.game.Open && !m.peeking(c, pileIdx, cardIdx) {
 		// Face-down card - use face-down colors for fill
 		fillStyle := styles.FaceDownCard
 		fill := fillStyle.Render(styles.FaceDownFill)
@@ -339,22 +357,23 @@ func (m model) renderCard(c *game.Card, pileIdx, cardIdx int, isOverlap bool) st
 			return borderTop + "\n" + borderL + fill + borderR
 		}
 
-		// Full 7-line card
-		return borderTop + "\n" +
-			borderL + fill + borderR + "\n" +
-			borderL + fill + borderR + "\n" +
-			borderL + fill + borderR + "\n" +
-			borderL + fill + borderR + "\n" +
-			borderL + fill + borderR + "\n" +
-			borderBottom
+		// Full card
+		row := borderL + fill + borderR
+		return m.frame(borderTop, borderBottom, row, row, row, row, row)
 	}
 
 	// Face Up card - determine content style
 	isRed := c.Suit.Color() == "Red"
 	var contentStyle lipgloss.Style
-	if isRed {
+	switch {
+	case !c.FaceUp && isRed:
+		// Face-down card of an open game, or one being peeked at
+		contentStyle = styles.OpenRedSuit
+	case !c.FaceUp:
+		contentStyle = styles.OpenBlackSuit
+	case isRed:
 		contentStyle = styles.RedSuit
-	} else {
+	default:
 		contentStyle = styles.BlackSuit
 	}
 
@@ -383,14 +402,23 @@ func (m model) renderCard(c *game.Card, pileIdx, cardIdx int, isOverlap bool) st
 		return borderTop + "\n" + borderL + inner2 + borderR
 	}
 
-	// Full 7-line card
-	return borderTop + "\n" +
-		borderL + inner2 + borderR + "\n" +
-		borderL + inner3 + borderR + "\n" +
-		borderL + inner4 + borderR + "\n" +
-		borderL + inner5 + borderR + "\n" +
-		borderL + inner6 + borderR + "\n" +
-		borderBottom
+	// Full card
+	return m.frame(borderTop, borderBottom,
+		borderL+inner2+borderR,
+		borderL+inner3+borderR,
+		borderL+inner4+borderR,
+		borderL+inner5+borderR,
+		borderL+inner6+borderR)
+}
+
+// frame stacks the five rows inside a card between its top and bottom
+// borders. Compact cards leave out the second and fourth row, which are blank
+// on a face-up card.
+func (m model) frame(top, bottom string, rows ...string) string {
+	if m.config.CardSize == config.CardsCompact {
+		rows = []string{rows[0], rows[2], rows[4]}
+	}
+	return top + "\n" + strings.Join(rows, "\n") + "\n" + bottom
 }
 
 // renderHelpOverlay renders the help popup
@@ -411,9 +439,106 @@ func (m model) renderHelpOverlay() string {
   Enter     Select / Move
   d / dd    Draw from Stock
   Esc       Cancel selection
+  p         Peek at a hidden card (costs points)
+  a         Show the solution (any key takes over)
+  n         New game / pick difficulty
+  R         Restart this deal
+  x         Resign the game
+  s         Statistics
+  c         Daily challenge calendar
+  r         House rules
+  m         Main menu
   q         Quit
 
+  The footer shows the win chance of the position and of each
+  move from the selected pile, or the pile under the cursor.
+
   Press ? or Esc to close
 `
+	if m.config.Keymap == config.KeymapEmacs {
+		help = strings.Replace(help, "\n  ACTIONS", "  C-b C-f   Move left / right\n  C-p C-n   Move up / down\n  C-a C-e   Jump to Stock / Tableau 7\n  C-g       Cancel selection\n\n  ACTIONS", 1)
+	}
 	return styles.HelpOverlay.Render(help)
 }
+
+// renderStatsOverlay renders the statistics screen
+func (m model) renderStatsOverlay() string {
+	var b strings.Builder
+	b.WriteString("\n  ♠ STATISTICS ♥\n  ────────────\n\n")
+
+	if m.stats != nil {
+		_ = m.stats.WriteText(&b)
+	} else {
+		b.WriteString("Statistics are not available.\n")
+	}
+	if m.statsErr != nil {
+		b.WriteString("\n" + styles.ErrorStyle.Render("⚠ "+m.statsErr.Error()) + "\n")
+	}
+
+	b.WriteString("\n  " + styles.HelpStyle.Render("Par or better counts wins in no more moves than the deal's par.") + "\n")
+	b.WriteString("\n  Press s or Esc to close\n")
+	return styles.HelpOverlay.Render(b.String())
+}
+
+// renderCalendarOverlay renders a month of daily challenge results
+func (m model) renderCalendarOverlay(month time.Time) string {
+	var b strings.Builder
+	b.WriteString("\n  ♠ DAILY CHALLENGE ♥\n  ─────────────────\n\n")
+
+	b.WriteString(fmt.Sprintf("  %s\n\n", month.Format("January 2006")))
+	b.WriteString("  Mo Tu We Th Fr Sa Su\n  ")
+
+	today := time.Now().UTC()
+	// Pad up to the first weekday, counting Monday as the first column
+	offset := (int(month.Weekday()) + 6) % 7
+	b.WriteString(strings.Repeat("   ", offset))
+	for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", day.Day())
+		var entry *stats.DailyEntry
+		if m.dailyLog != nil {
+			entry = m.dailyLog.Entry(day)
+		}
+		switch {
+		case entry != nil && entry.Solved:
+			cell = styles.SuccessStyle.Render(cell)
+		case entry != nil:
+			cell = styles.ErrorStyle.Render(cell)
+		}
+		if stats.DayKey(day) == stats.DayKey(today) {
+			cell = lipgloss.NewStyle().Underline(true).Render(cell)
+		}
+		b.WriteString(cell + " ")
+		if (offset+day.Day())%7 == 0 {
+			b.WriteString("\n  ")
+		}
+	}
+
+	b.WriteString("\n\n  " + styles.SuccessStyle.Render("■") + " solved  " +
+		styles.ErrorStyle.Render("■") + " attempted  " +
+		lipgloss.NewStyle().Underline(true).Render("today") + "\n")
+	if m.dailyLog != nil {
+		if e := m.dailyLog.Entry(today); e != nil && e.Solved {
+			b.WriteString(fmt.Sprintf("\n  Today: solved in %d moves (%s)\n", e.Moves, time.Duration(e.Secs)*time.Second))
+		}
+	}
+	if m.dailyErr != nil {
+		b.WriteString("\n" + styles.ErrorStyle.Render("⚠ "+m.dailyErr.Error()) + "\n")
+	}
+
+	b.WriteString("\n  h/l: previous/next month\n  Press c or Esc to close\n")
+	return styles.HelpOverlay.Render(b.String())
+}
+
+// startOfMonth returns midnight UTC on the first day of t's month.
+func startOfMonth(t time.Time) time.Time {
+	y, mo, _ := t.UTC().Date()
+	return time.Date(y, mo, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// sentence capitalises the first letter of an error message for display.
+func sentence(msg string) string {
+	if msg == "" {
+		return msg
+	}
+	return strings.ToUpper(msg[:1]) + msg[1:]
+}
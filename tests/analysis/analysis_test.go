@@ -0,0 +1,129 @@
+package analysis_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// board builds a game from cards pushed onto an empty board.
+func board(setup func(g *game.Game)) *game.Game {
+	g := game.FromState(game.State{})
+	setup(g)
+	return g
+}
+
+func card(r game.Rank, s game.Suit, up bool) *game.Card {
+	return &game.Card{Rank: r, Suit: s, FaceUp: up}
+}
+
+func TestPatterns(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(g *game.Game)
+		want  []string
+	}{
+		{
+			name: "Playable",
+			setup: func(g *game.Game) {
+				g.Tableaus[0].Push(card(game.King, game.Spades, true))
+				g.Tableaus[1].Push(card(game.Queen, game.Hearts, true))
+			},
+			want: nil,
+		},
+		{
+			name: "KingInStockOnFirstPass",
+			setup: func(g *game.Game) {
+				g.Tableaus[0].Push(card(game.Ace, game.Spades, true))
+				g.Stock.Push(card(game.King, game.Hearts, false))
+			},
+			want: nil,
+		},
+		{
+			name: "BuriedAceAndNoKings",
+			setup: func(g *game.Game) {
+				g.Tableaus[3].Push(card(game.Ace, game.Clubs, false))
+				g.Tableaus[3].Push(card(game.King, game.Clubs, false))
+				g.Tableaus[3].Push(card(game.Four, game.Hearts, false))
+				g.Tableaus[3].Push(card(game.Nine, game.Spades, true))
+				g.Tableaus[4].Push(card(game.Three, game.Hearts, true))
+			},
+			want: []string{analysis.KingBottleneck, analysis.BuriedAce, analysis.DeadOnArrival},
+		},
+		{
+			name: "WastePlayLater",
+			setup: func(g *game.Game) {
+				g.Tableaus[0].Push(card(game.Nine, game.Spades, true))
+				g.Stock.Push(card(game.Eight, game.Hearts, false))
+				g.Stock.Push(card(game.Two, game.Clubs, false))
+			},
+			want: []string{analysis.KingBottleneck},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := analysis.Patterns(board(tt.setup)); !slices.Equal(got, tt.want) {
+				t.Errorf("Patterns = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDeals(t *testing.T) {
+	reports, err := analysis.AnalyzeDeals(context.Background(), analysis.DealOptions{
+		From: 2, To: 6, Rules: game.DefaultRules(), MaxNodes: 2000, Workers: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 5 {
+		t.Fatalf("got %d reports, want 5", len(reports))
+	}
+	for i, r := range reports {
+		if r.Seed != int64(2+i) {
+			t.Errorf("report %d has seed %d, want %d", i, r.Seed, 2+i)
+		}
+		if r.Winnable == analysis.WinnableYes && r.SolutionLength == 0 {
+			t.Errorf("seed %d: winnable without a solution length", r.Seed)
+		}
+	}
+
+	var js bytes.Buffer
+	if err := analysis.WriteJSON(&js, reports); err != nil {
+		t.Fatal(err)
+	}
+	var back []analysis.DealReport
+	if err := json.Unmarshal(js.Bytes(), &back); err != nil || len(back) != 5 {
+		t.Errorf("JSON did not round-trip: %v\n%s", err, js.String())
+	}
+
+	var csv bytes.Buffer
+	if err := analysis.WriteCSV(&csv, reports); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(lines) != 6 || lines[0] != "seed,winnable,solution_length,nodes,patterns" {
+		t.Errorf("unexpected CSV:\n%s", csv.String())
+	}
+}
+
+func TestWriteSummary_NoWinFound(t *testing.T) {
+	reports := []analysis.DealReport{
+		{Seed: 1, Winnable: analysis.WinnableYes},
+		{Seed: 2, Winnable: analysis.WinnableNotFound, Patterns: []string{analysis.BuriedAce}},
+		{Seed: 3, Winnable: analysis.WinnableUnknown},
+	}
+	var out bytes.Buffer
+	if err := analysis.WriteSummary(&out, reports); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "1 no win found (33.3%)") || strings.Contains(out.String(), "unwinnable") {
+		t.Errorf("summary should not call pruned failures unwinnable:\n%s", out.String())
+	}
+}
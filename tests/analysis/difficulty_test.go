@@ -0,0 +1,58 @@
+package analysis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	for _, d := range analysis.Difficulties {
+		got, err := analysis.ParseDifficulty(d.String())
+		if err != nil || got != d {
+			t.Errorf("ParseDifficulty(%q) = %v, %v", d, got, err)
+		}
+	}
+	for _, name := range []string{"brutal", "unrated", "no win found"} {
+		if _, err := analysis.ParseDifficulty(name); err == nil {
+			t.Errorf("ParseDifficulty accepted %q", name)
+		}
+	}
+}
+
+func TestRate(t *testing.T) {
+	g := game.NewGameFromSeed(5)
+	r := analysis.Rate(context.Background(), g, 0)
+	if r.SolutionLength == 0 || r.Difficulty < analysis.Easy || r.Difficulty > analysis.Expert {
+		t.Fatalf("seed 5 rated %+v, want a solved rating", r)
+	}
+	if again := analysis.Rate(context.Background(), game.NewGameFromSeed(5), 0); again != r {
+		t.Errorf("rating is not deterministic: %+v then %+v", r, again)
+	}
+	if len(g.History) != 0 {
+		t.Error("Rate modified the game")
+	}
+
+	if r := analysis.Rate(context.Background(), game.NewGameFromSeed(5), 3); r.Difficulty != analysis.Unrated {
+		t.Errorf("a 3-node budget rated %v, want unrated", r.Difficulty)
+	}
+}
+
+func TestFindDeal(t *testing.T) {
+	seed, r, err := analysis.FindDeal(context.Background(), analysis.Easy, game.DefaultRules(), 1, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Difficulty != analysis.Easy {
+		t.Errorf("found a %v deal, want easy", r.Difficulty)
+	}
+	if again := analysis.Rate(context.Background(), game.NewGameFromSeed(seed), 0); again.Difficulty != analysis.Easy {
+		t.Errorf("seed %d re-rated %v", seed, again.Difficulty)
+	}
+
+	if _, _, err := analysis.FindDeal(context.Background(), analysis.Expert, game.DefaultRules(), 1, 0); err == nil {
+		t.Error("FindDeal with no tries should fail")
+	}
+}
@@ -0,0 +1,74 @@
+package analysis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+// noRedeals makes drawing through the stock without playing a real risk.
+var noRedeals = game.Rules{DrawCount: 1, Redeals: 0, FoundationToTableau: true, PartialStacks: true}
+
+// drawOut draws the whole stock without playing a card.
+func drawOut(seed int64) *game.Game {
+	g := game.NewGameWithRules(seed, noRedeals)
+	for len(g.Stock.Cards) > 0 {
+		g.DrawCard()
+	}
+	return g
+}
+
+func TestFindMistake(t *testing.T) {
+	g := drawOut(9)
+	m, err := analysis.FindMistake(context.Background(), g.Seed, g.Rules, g.History, 20000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Move != g.History[m.Index] {
+		t.Errorf("Move = %s, want move %d of the history, %s", m.Move, m.Index, g.History[m.Index])
+	}
+
+	// The alternative must win from the position before the blunder
+	replay := game.NewGameWithRules(g.Seed, g.Rules)
+	for _, mv := range g.History[:m.Index] {
+		replay.Apply(mv)
+	}
+	for _, mv := range m.Alternative {
+		if err := replay.Apply(mv); err != nil {
+			t.Fatalf("alternative move %s: %v", mv, err)
+		}
+	}
+	if !replay.IsWon {
+		t.Error("the alternative line does not win")
+	}
+
+	// The blunder is the last move played from a position the solver can win
+	later := game.NewGameWithRules(g.Seed, g.Rules)
+	for i, mv := range g.History {
+		later.Apply(mv)
+		if i < m.Index {
+			continue
+		}
+		if r := solver.Solve(context.Background(), later, solver.Options{MaxNodes: 20000}); r.Status == solver.Solved {
+			t.Errorf("the position after move %d can be won, but the blunder is move %d", i, m.Index)
+		}
+	}
+}
+
+func TestFindMistake_NoMistake(t *testing.T) {
+	g := game.NewGameFromSeed(5)
+	g.DrawCard()
+	g.DrawCard()
+	if _, err := analysis.FindMistake(context.Background(), g.Seed, g.Rules, g.History, 20000); !errors.Is(err, analysis.ErrStillWinnable) {
+		t.Errorf("err = %v, want ErrStillWinnable", err)
+	}
+
+	g = drawOut(3)
+	if _, err := analysis.FindMistake(context.Background(), g.Seed, g.Rules, g.History, 20000); !errors.Is(err, analysis.ErrDealUnwinnable) {
+		t.Errorf("err = %v, want ErrDealUnwinnable", err)
+	}
+}
@@ -0,0 +1,130 @@
+package analysis_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/analysis"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestSample_KeepsVisibleCards(t *testing.T) {
+	g := game.NewGameFromSeed(8)
+	g.DrawCard()
+	s := analysis.Sample(g, rand.New(rand.NewSource(1)))
+
+	if err := s.Validate(); err != nil {
+		t.Fatalf("sample is not a valid board: %v", err)
+	}
+	changed := false
+	for i := game.StockPile; i <= game.TableauPile7; i++ {
+		a, b := g.GetPile(i).Cards, s.GetPile(i).Cards
+		if len(a) != len(b) {
+			t.Fatalf("pile %d has %d cards in the sample, want %d", i, len(b), len(a))
+		}
+		for j := range a {
+			if a[j].FaceUp != b[j].FaceUp {
+				t.Fatalf("pile %d card %d changed face", i, j)
+			}
+			if a[j].FaceUp && *a[j] != *b[j] {
+				t.Errorf("visible card %v became %v", *a[j], *b[j])
+			}
+			if *a[j] != *b[j] {
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		t.Error("no hidden card moved")
+	}
+	if g.Waste.Peek() == s.Waste.Peek() {
+		t.Error("sample shares cards with the game")
+	}
+}
+
+func TestSample_SeenStockIsKept(t *testing.T) {
+	g := game.NewGameFromSeed(8)
+	for len(g.Stock.Cards) > 0 {
+		g.DrawCard()
+	}
+	g.RecycleWaste()
+	s := analysis.Sample(g, rand.New(rand.NewSource(1)))
+	for j, c := range g.Stock.Cards {
+		if *c != *s.Stock.Cards[j] {
+			t.Fatalf("stock card %d changed after the player has seen it", j)
+		}
+	}
+}
+
+func TestSample_CardsSeenBeforeAreKept(t *testing.T) {
+	g := game.NewGameFromSeed(8)
+	if _, err := g.Peek(game.TableauPile7, 0); err != nil {
+		t.Fatal(err)
+	}
+	g.DrawCard()
+	g.Undo() // The drawn card goes back into the stock, seen
+	peeked, drawn := *g.Tableaus[6].Cards[0], *g.Stock.Peek()
+
+	for seed := int64(1); seed <= 20; seed++ {
+		s := analysis.Sample(g, rand.New(rand.NewSource(seed)))
+		if *s.Tableaus[6].Cards[0] != peeked {
+			t.Fatalf("seed %d: peeked card %v became %v", seed, peeked, *s.Tableaus[6].Cards[0])
+		}
+		if *s.Stock.Peek() != drawn {
+			t.Fatalf("seed %d: undrawn card %v became %v", seed, drawn, *s.Stock.Peek())
+		}
+	}
+}
+
+func TestSample_OpenGameUnchanged(t *testing.T) {
+	g := game.NewOpenGame(8, game.DefaultRules())
+	s := analysis.Sample(g, rand.New(rand.NewSource(1)))
+	if s.State() != g.State() {
+		t.Error("sampling an open game moved cards the player can see")
+	}
+}
+
+func TestWinChance(t *testing.T) {
+	g := game.NewGameFromSeed(5)
+	opts := analysis.EstimateOptions{Samples: 4, MaxNodes: 2000, Seed: 1, PerMove: true}
+	total, moves := analysis.WinChance(context.Background(), g, opts)
+	if total.Samples != 4 || total.Wins+total.Unknown > 4 {
+		t.Errorf("estimate = %+v", total)
+	}
+	if len(moves) != len(g.LegalMoves()) {
+		t.Errorf("got %d move estimates, want one per legal move (%d)", len(moves), len(g.LegalMoves()))
+	}
+	for _, m := range moves {
+		if m.Samples != 4 {
+			t.Errorf("%s: %d samples, want 4", m.Move, m.Samples)
+		}
+	}
+	if len(g.History) != 0 {
+		t.Error("WinChance modified the game")
+	}
+
+	if _, ok := (analysis.Estimate{}).Chance(); ok {
+		t.Error("an empty estimate reported a chance")
+	}
+	if p, _ := (analysis.Estimate{Samples: 4, Wins: 1, Unknown: 2}).Chance(); p != 0.25 {
+		t.Errorf("Chance = %v, want 0.25 with undecided samples counted as lost", p)
+	}
+}
+
+func TestWinChance_Progress(t *testing.T) {
+	var seen []analysis.Estimate
+	total, _ := analysis.WinChance(context.Background(), game.NewGameFromSeed(5), analysis.EstimateOptions{
+		Samples:  3,
+		MaxNodes: 500,
+		Progress: func(e analysis.Estimate) { seen = append(seen, e) },
+	})
+	if len(seen) != 3 || seen[2] != total {
+		t.Errorf("progress = %+v, want 3 running estimates ending at %+v", seen, total)
+	}
+	for i, e := range seen {
+		if e.Samples != i+1 {
+			t.Errorf("estimate %d covers %d samples", i, e.Samples)
+		}
+	}
+}
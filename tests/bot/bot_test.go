@@ -0,0 +1,109 @@
+package bot_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/bot"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// lastMove always plays the last legal move.
+type lastMove struct{ finished []bot.Result }
+
+func (p *lastMove) Choose(g *game.Game, legal []game.Move) (game.Move, error) {
+	return legal[len(legal)-1], nil
+}
+
+func (p *lastMove) Finish(g *game.Game, r bot.Result) error {
+	p.finished = append(p.finished, r)
+	return nil
+}
+
+type resigner struct{}
+
+func (resigner) Choose(*game.Game, []game.Move) (game.Move, error) {
+	return game.Move{}, bot.ErrResign
+}
+
+func TestPlay_StopsAtMaxMoves(t *testing.T) {
+	p := &lastMove{}
+	r, err := bot.Play(p, game.NewGameFromSeed(1), 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Seed != 1 || r.Moves > 50 {
+		t.Errorf("result = %+v", r)
+	}
+	if len(p.finished) != 1 || p.finished[0] != r {
+		t.Errorf("Finish got %v, want [%+v]", p.finished, r)
+	}
+}
+
+func TestPlay_Resign(t *testing.T) {
+	r, err := bot.Play(resigner{}, game.NewGameFromSeed(1), 50)
+	if err != nil || !r.Resigned || r.Won || r.Moves != 0 {
+		t.Errorf("Play = %+v, %v; want an immediate resignation", r, err)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	g := game.NewGameFromSeed(1)
+	g.DrawCard()
+	want := "S:23 W:7S F1:- F2:- F3:- F4:- T1:7D T2:#,4D T3:#,#,5S T4:#,#,#,JC T5:#,#,#,#,JH T6:#,#,#,#,#,6S T7:#,#,#,#,#,#,8S"
+	if got := bot.Position(g); got != want {
+		t.Errorf("Position =\n%s\nwant\n%s", got, want)
+	}
+	if got := bot.MoveToken(game.Move{Kind: game.MoveTransfer, From: game.TableauPile5, Index: 2, To: game.FoundationPile1}); got != "T5:2->F1" {
+		t.Errorf("MoveToken = %q", got)
+	}
+}
+
+func TestEngine(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh to run the test engine")
+	}
+	e, err := bot.StartEngine(5*time.Second, "sh", "testdata/firstmove.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	var sum bot.Summary
+	for seed := int64(1); seed <= 3; seed++ {
+		g := game.NewGameFromSeed(seed)
+		r, err := bot.Play(e, g, 40)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		// The first legal move is always the draw or recycle
+		for _, m := range g.History {
+			if m.Kind == game.MoveTransfer {
+				t.Fatalf("seed %d: engine played %s, not the first legal move", seed, m)
+			}
+		}
+		sum.Add(r)
+	}
+	if sum.Games != 3 || sum.Moves != 120 {
+		t.Errorf("summary = %+v, want 3 games of 40 moves", sum)
+	}
+}
+
+func TestEngine_IllegalReply(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh to run the test engine")
+	}
+	script := `while read -r cmd rest; do case $cmd in solitaire) echo ready;; go) echo "move T1->F1";; quit) exit 0;; esac; done`
+	e, err := bot.StartEngine(5*time.Second, "sh", "-c", script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	_, err = bot.Play(e, game.NewGameFromSeed(1), 10)
+	if err == nil || !strings.Contains(err.Error(), "not a legal move") {
+		t.Errorf("Play error = %v, want an illegal move error", err)
+	}
+}
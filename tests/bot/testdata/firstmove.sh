@@ -0,0 +1,10 @@
+#!/bin/sh
+# A minimal engine that always plays the first legal move.
+while read -r cmd rest; do
+	case $cmd in
+	solitaire) echo "info name firstmove"; echo ready ;;
+	moves) set -- $rest; first=$1 ;;
+	go) echo "move $first" ;;
+	quit) exit 0 ;;
+	esac
+done
@@ -0,0 +1,69 @@
+package bot_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/bot"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestRunTournament_WorkersDoNotChangeResults(t *testing.T) {
+	var entrants []bot.Entrant
+	for _, name := range []string{"random", "greedy", "heuristic"} {
+		e, err := bot.BuiltIn(name, 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entrants = append(entrants, e)
+	}
+	opts := bot.TournamentOptions{Seed: 1, Games: 8, Rules: game.DefaultRules(), MaxMoves: 300, Workers: 1}
+
+	one, err := bot.RunTournament(context.Background(), entrants, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.Workers = 4
+	four, err := bot.RunTournament(context.Background(), entrants, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range one {
+		if one[i] != four[i] {
+			t.Errorf("%s: 1 worker %+v, 4 workers %+v", one[i].Name, one[i].Summary, four[i].Summary)
+		}
+		if one[i].Games != 8 {
+			t.Errorf("%s played %d games, want 8", one[i].Name, one[i].Games)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := bot.WriteStandings(&out, one); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 4 || !strings.HasPrefix(lines[3], "heuristic") {
+		t.Errorf("unexpected table:\n%s", out.String())
+	}
+}
+
+func TestBuiltIn_Unknown(t *testing.T) {
+	if _, err := bot.BuiltIn("oracle", 0); err == nil {
+		t.Error("BuiltIn accepted an unknown player")
+	}
+}
+
+func TestSummary_Intervals(t *testing.T) {
+	var s bot.Summary
+	for i := 0; i < 100; i++ {
+		s.Add(bot.Result{Won: i%4 == 0, Moves: 100 + i%3})
+	}
+	lo, hi := s.WinRateInterval()
+	if !(lo < 0.25 && 0.25 < hi) || lo < 0.15 || hi > 0.35 {
+		t.Errorf("win rate interval = %.3f-%.3f, want around 0.25", lo, hi)
+	}
+	if m := s.MovesMargin(); m <= 0 || m > 1 {
+		t.Errorf("moves margin = %.3f, want a small positive spread", m)
+	}
+}
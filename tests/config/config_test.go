@@ -0,0 +1,87 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/config"
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	c, err := config.Load(filepath.Join(t.TempDir(), config.FileName))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if c.Rules() != game.DefaultRules() || c.Scoring != config.ScoringStandard || !c.Analysis {
+		t.Errorf("got %+v, want the defaults", c)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", config.FileName)
+	c, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	c.Draw = 3
+	c.Redeals = "2"
+	c.Theme = config.ThemeMidnight
+	c.AutoPlay = true
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if *loaded != *c {
+		t.Errorf("loaded %+v, want %+v", loaded, c)
+	}
+	if r := loaded.Rules(); r.DrawCount != 3 || r.Redeals != 2 {
+		t.Errorf("Rules() = %+v, want draw 3 with 2 redeals", r)
+	}
+}
+
+func TestLoad_PartialFileKeepsDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.FileName)
+	if err := os.WriteFile(path, []byte(`{"keymap": "emacs"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if c.Keymap != config.KeymapEmacs || c.Draw != 1 || !c.Animations {
+		t.Errorf("got %+v, want emacs keys over the defaults", c)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for name, tt := range map[string]struct{ data, want string }{
+		"syntax":  {`{"draw": 3`, "unexpected EOF"},
+		"unknown": {`{"colour": "red"}`, `unknown field "colour"`},
+		"draw":    {`{"draw": 2}`, "must be 1 or 3"},
+		"redeals": {`{"redeals": "lots"}`, "redeals=lots"},
+		"scoring": {`{"scoring": "golf"}`, "must be standard, vegas or none"},
+		"theme":   {`{"theme": "pink"}`, `theme "pink"`},
+	} {
+		path := filepath.Join(t.TempDir(), config.FileName)
+		if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := config.Load(path)
+		if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), path) {
+			t.Errorf("%s: Load() error = %v, want one naming the file and %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestSave_DefaultsHaveNoFile(t *testing.T) {
+	if err := config.Default().Save(); err != nil {
+		t.Errorf("Save() error = %v", err)
+	}
+}
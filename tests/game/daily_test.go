@@ -0,0 +1,40 @@
+package game_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestDailySeed(t *testing.T) {
+	// Same UTC date from different time zones gives the same seed
+	utc := time.Date(2026, 1, 17, 12, 0, 0, 0, time.UTC)
+	tokyo := utc.In(time.FixedZone("JST", 9*60*60))
+
+	if game.DailySeed(utc) != 20260117 {
+		t.Errorf("DailySeed() = %d, want 20260117", game.DailySeed(utc))
+	}
+	if game.DailySeed(tokyo) != game.DailySeed(utc) {
+		t.Errorf("daily seed depends on time zone")
+	}
+}
+
+func TestShuffle_Stable(t *testing.T) {
+	// Seeds must deal the same cards everywhere, so pin the first few cards.
+	deck := game.NewDeck()
+	game.Shuffle(deck, 1)
+
+	want := []game.Card{
+		{Rank: game.Seven, Suit: game.Diamonds},
+		{Rank: game.Nine, Suit: game.Clubs},
+		{Rank: game.Four, Suit: game.Diamonds},
+		{Rank: game.Eight, Suit: game.Hearts},
+		{Rank: game.Eight, Suit: game.Diamonds},
+	}
+	for i, w := range want {
+		if *deck[i] != w {
+			t.Errorf("card %d = %v%v, want %v%v", i, deck[i].Rank, deck[i].Suit, w.Rank, w.Suit)
+		}
+	}
+}
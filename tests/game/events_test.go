@@ -0,0 +1,102 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestEvents_MoveWithFlip(t *testing.T) {
+	g := setupGameWithSpecificCards(t, func(g *game.Game) {
+		g.Tableaus[0].Push(&game.Card{Rank: game.Six, Suit: game.Clubs, FaceUp: false})
+		g.Tableaus[0].Push(&game.Card{Rank: game.Five, Suit: game.Hearts, FaceUp: true})
+		g.Tableaus[1].Push(&game.Card{Rank: game.Six, Suit: game.Spades, FaceUp: true})
+	})
+	var events []game.Event
+	g.Subscribe(func(e game.Event) { events = append(events, e) })
+
+	g.Move(game.TableauPile1, 1, game.TableauPile2)
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %#v", len(events), events)
+	}
+	moved, ok := events[0].(game.CardMoved)
+	if !ok || len(moved.Cards) != 1 || moved.Cards[0].Rank != game.Five || moved.Move.To != game.TableauPile2 {
+		t.Errorf("first event = %#v, want CardMoved of the Five", events[0])
+	}
+	flipped, ok := events[1].(game.CardFlipped)
+	if !ok || flipped.Pile != game.TableauPile1 || flipped.Card.Rank != game.Six || !flipped.Card.FaceUp {
+		t.Errorf("second event = %#v, want CardFlipped of the Six", events[1])
+	}
+}
+
+func TestEvents_DrawAndRecycle(t *testing.T) {
+	g := game.NewGameFromSeed(3)
+	var drawn, recycled int
+	g.Subscribe(func(e game.Event) {
+		switch e := e.(type) {
+		case game.StockDrawn:
+			drawn += len(e.Cards)
+		case game.WasteRecycled:
+			recycled += e.Count
+		}
+	})
+
+	for len(g.Stock.Cards) > 0 {
+		g.DrawCard()
+	}
+	g.RecycleWaste()
+
+	if drawn != 24 || recycled != 24 {
+		t.Errorf("drawn=%d recycled=%d, want 24/24", drawn, recycled)
+	}
+}
+
+func TestEvents_FoundationCompletedAndWon(t *testing.T) {
+	g := setupGameWithSpecificCards(t, func(g *game.Game) {
+		suits := []game.Suit{game.Spades, game.Hearts, game.Diamonds, game.Clubs}
+		for i, suit := range suits {
+			for rank := game.Ace; rank <= game.King; rank++ {
+				if i == 3 && rank == game.King {
+					break
+				}
+				g.Foundations[i].Push(&game.Card{Suit: suit, Rank: rank, FaceUp: true})
+			}
+		}
+		g.Waste.Push(&game.Card{Suit: game.Clubs, Rank: game.King, FaceUp: true})
+	})
+	var completed, won int
+	g.Subscribe(func(e game.Event) {
+		switch e.(type) {
+		case game.FoundationCompleted:
+			completed++
+		case game.GameWon:
+			won++
+		}
+	})
+
+	if !g.Move(game.WastePile, 0, game.FoundationPile4) {
+		t.Fatal("final move failed")
+	}
+
+	if completed != 1 || won != 1 || !g.IsWon {
+		t.Errorf("completed=%d won=%d IsWon=%v, want 1/1/true", completed, won, g.IsWon)
+	}
+}
+
+func TestEvents_UnsubscribeAndClone(t *testing.T) {
+	g := game.NewGameFromSeed(3)
+	calls := 0
+	unsubscribe := g.Subscribe(func(game.Event) { calls++ })
+
+	g.Clone().DrawCard()
+	if calls != 0 {
+		t.Errorf("clone delivered %d events to the original's observer", calls)
+	}
+
+	unsubscribe()
+	g.DrawCard()
+	if calls != 0 {
+		t.Errorf("observer called %d times after unsubscribing", calls)
+	}
+}
@@ -1,6 +1,7 @@
 package game_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/solitaire-tui/solitaire-tui/internal/game"
@@ -190,10 +191,11 @@ func TestMove(t *testing.T) {
 			expectedSuccess: false,
 			expectedFlip:    false,
 		},
-		// Foundation to Tableau: Valid (Ace to Empty Tableau)
+		// Foundation to Tableau: Valid (Ace to Empty Tableau when any card may fill it)
 		{
 			name: "FoundationToTableau_ValidAce",
 			setup: func(g *game.Game) {
+				g.Rules.AnyCardOnEmpty = true
 				g.Foundations[0].Push(&game.Card{Rank: game.Ace, Suit: game.Spades, FaceUp: true})
 				g.Tableaus[0].Cards = nil // Ensure Tableau is empty
 			},
@@ -463,3 +465,88 @@ func TestMove_FoundationWrongSuit(t *testing.T) {
 		t.Errorf("Should not be able to move wrong suit to foundation")
 	}
 }
+
+func TestTryMove_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(g *game.Game)
+		src   int
+		idx   int
+		dst   int
+		want  error
+	}{
+		{
+			name: "FaceDownInStack",
+			setup: func(g *game.Game) {
+				g.Tableaus[0].Push(&game.Card{Rank: game.Six, Suit: game.Clubs, FaceUp: false})
+				g.Tableaus[0].Push(&game.Card{Rank: game.Five, Suit: game.Hearts, FaceUp: true})
+				g.Tableaus[1].Push(&game.Card{Rank: game.Seven, Suit: game.Hearts, FaceUp: true})
+			},
+			src: game.TableauPile1, idx: 0, dst: game.TableauPile2,
+			want: game.ErrFaceDown,
+		},
+		{
+			name: "KingOnlyOnEmpty",
+			setup: func(g *game.Game) {
+				g.Waste.Push(&game.Card{Rank: game.Queen, Suit: game.Hearts, FaceUp: true})
+			},
+			src: game.WastePile, idx: 0, dst: game.TableauPile1,
+			want: game.ErrKingOnly,
+		},
+		{
+			name: "WrongColour",
+			setup: func(g *game.Game) {
+				g.Waste.Push(&game.Card{Rank: game.Five, Suit: game.Hearts, FaceUp: true})
+				g.Tableaus[0].Push(&game.Card{Rank: game.Six, Suit: game.Diamonds, FaceUp: true})
+			},
+			src: game.WastePile, idx: 0, dst: game.TableauPile1,
+			want: game.ErrWrongColor,
+		},
+		{
+			name: "WrongRank",
+			setup: func(g *game.Game) {
+				g.Waste.Push(&game.Card{Rank: game.Four, Suit: game.Hearts, FaceUp: true})
+				g.Tableaus[0].Push(&game.Card{Rank: game.Six, Suit: game.Clubs, FaceUp: true})
+			},
+			src: game.WastePile, idx: 0, dst: game.TableauPile1,
+			want: game.ErrWrongRank,
+		},
+		{
+			name: "FoundationWrongSuit",
+			setup: func(g *game.Game) {
+				g.Foundations[0].Push(&game.Card{Rank: game.Ace, Suit: game.Spades, FaceUp: true})
+				g.Waste.Push(&game.Card{Rank: game.Two, Suit: game.Hearts, FaceUp: true})
+			},
+			src: game.WastePile, idx: 0, dst: game.FoundationPile1,
+			want: game.ErrFoundationSuit,
+		},
+		{
+			name: "MultipleToFoundation",
+			setup: func(g *game.Game) {
+				g.Foundations[0].Push(&game.Card{Rank: game.Ace, Suit: game.Spades, FaceUp: true})
+				g.Tableaus[0].Push(&game.Card{Rank: game.Two, Suit: game.Spades, FaceUp: true})
+				g.Tableaus[0].Push(&game.Card{Rank: game.Ace, Suit: game.Hearts, FaceUp: true})
+			},
+			src: game.TableauPile1, idx: 0, dst: game.FoundationPile1,
+			want: game.ErrMultipleToFoundation,
+		},
+		{
+			name:  "InvalidPile",
+			setup: func(g *game.Game) {},
+			src:   game.WastePile, idx: 0, dst: 42,
+			want: game.ErrInvalidPile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := setupGameWithSpecificCards(t, tt.setup)
+
+			err := g.TryMove(tt.src, tt.idx, tt.dst)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("TryMove() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestLegalMoves_AllApply(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		g := game.NewGameWithRules(seed, game.Rules{DrawCount: 3, Redeals: 1, StockToTableau: true})
+		for step := 0; step < 200; step++ {
+			legal := g.LegalMoves()
+			if len(legal) == 0 {
+				break
+			}
+			for _, m := range legal {
+				if err := g.Clone().Apply(m); err != nil {
+					t.Fatalf("seed %d: legal move %s failed: %v", seed, m, err)
+				}
+			}
+			// Prefer transfers so the game gets somewhere
+			g.Apply(legal[len(legal)-1])
+		}
+	}
+}
+
+func TestLegalMoves_StockAndRecycle(t *testing.T) {
+	g := game.NewGameWithRules(5, game.Rules{DrawCount: 1, Redeals: 0})
+	if legal := g.LegalMoves(); len(legal) == 0 || legal[0].Kind != game.MoveDraw {
+		t.Fatalf("a fresh deal should offer a draw first, got %v", legal)
+	}
+	for len(g.Stock.Cards) > 0 {
+		g.DrawCard()
+	}
+	for _, m := range g.LegalMoves() {
+		if m.Kind != game.MoveTransfer {
+			t.Errorf("offered %s with an empty stock and no redeals", m)
+		}
+	}
+}
+
+func TestLegalMoves_FirstEmptyPileOnly(t *testing.T) {
+	g := setupGameWithSpecificCards(t, func(g *game.Game) {
+		g.Tableaus[0].Push(&game.Card{Rank: game.Ace, Suit: game.Spades, FaceUp: true})
+		g.Tableaus[1].Push(&game.Card{Rank: game.Five, Suit: game.Clubs, FaceUp: false})
+		g.Tableaus[1].Push(&game.Card{Rank: game.King, Suit: game.Hearts, FaceUp: true})
+	})
+	var toFoundation, toEmpty int
+	for _, m := range g.LegalMoves() {
+		switch {
+		case m.To >= game.FoundationPile1 && m.To <= game.FoundationPile4:
+			toFoundation++
+		case m.From == game.TableauPile2:
+			toEmpty++
+			if m.To != game.TableauPile3 {
+				t.Errorf("King offered to %s, want only the first empty column T3", game.PileName(m.To))
+			}
+		}
+	}
+	if toFoundation != 1 || toEmpty != 1 {
+		t.Errorf("got %d foundation and %d empty-column moves, want 1 each", toFoundation, toEmpty)
+	}
+}
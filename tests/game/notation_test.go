@@ -0,0 +1,159 @@
+package game_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+// playSome makes a deterministic series of legal moves, drawing when nothing else applies.
+func playSome(g *game.Game, n int) {
+	for step := 0; step < n; step++ {
+		moved := false
+		for src := game.WastePile; src <= game.TableauPile7 && !moved; src++ {
+			idx := g.GetActiveCardIndex(src)
+			if src >= game.TableauPile1 {
+				// Move the whole face-up run
+				pile := g.GetPile(src)
+				for idx > 0 && pile.Cards[idx-1].FaceUp {
+					idx--
+				}
+			}
+			for dst := game.FoundationPile1; dst <= game.TableauPile7 && !moved; dst++ {
+				if dst != src && idx >= 0 && g.Move(src, idx, dst) {
+					moved = true
+				}
+			}
+		}
+		if !moved {
+			if len(g.Stock.Cards) > 0 {
+				g.DrawCard()
+			} else {
+				g.RecycleWaste()
+			}
+		}
+	}
+}
+
+func sameBoard(t *testing.T, a, b *game.Game) {
+	t.Helper()
+	for i := game.StockPile; i <= game.TableauPile7; i++ {
+		pa, pb := a.GetPile(i), b.GetPile(i)
+		if len(pa.Cards) != len(pb.Cards) {
+			t.Fatalf("pile %s: got %d cards, want %d", game.PileName(i), len(pb.Cards), len(pa.Cards))
+		}
+		for j := range pa.Cards {
+			if *pa.Cards[j] != *pb.Cards[j] {
+				t.Fatalf("pile %s card %d: got %+v, want %+v", game.PileName(i), j, *pb.Cards[j], *pa.Cards[j])
+			}
+		}
+	}
+}
+
+func TestNewGameFromSeed_Deterministic(t *testing.T) {
+	sameBoard(t, game.NewGameFromSeed(7), game.NewGameFromSeed(7))
+}
+
+func TestNotation_RoundTrip(t *testing.T) {
+	g := game.NewGameFromSeed(42)
+	playSome(g, 60)
+	if len(g.History) == 0 {
+		t.Fatal("expected some moves to be recorded")
+	}
+
+	text := g.Export()
+	if !strings.Contains(text, `[Seed "42"]`) {
+		t.Errorf("export missing seed header:\n%s", text)
+	}
+
+	imported, err := game.Import(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(imported.History) != len(g.History) {
+		t.Errorf("got %d moves, want %d", len(imported.History), len(g.History))
+	}
+	sameBoard(t, g, imported)
+}
+
+func TestNotation_Result(t *testing.T) {
+	g := game.NewGameFromSeed(42)
+	playSome(g, 10)
+	if !strings.Contains(g.Export(), `[Result "*"]`) {
+		t.Errorf("unfinished game not exported in progress:\n%s", g.Export())
+	}
+
+	g.Resign()
+	text := g.Export()
+	if !strings.Contains(text, `[Result "0-1"]`) {
+		t.Errorf("resigned game not exported as lost:\n%s", text)
+	}
+	imported, err := game.Import(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if !imported.Lost || imported.Result() != game.ResultLost {
+		t.Errorf("imported result = %s, want %s", imported.Result(), game.ResultLost)
+	}
+}
+
+func TestNotation_OpenGame(t *testing.T) {
+	g := game.NewOpenGame(42, game.DefaultRules())
+	playSome(g, 10)
+	sameBoard(t, game.NewGameFromSeed(42), game.NewOpenGame(42, game.DefaultRules()))
+
+	text := g.Export()
+	if !strings.Contains(text, `[Open "yes"]`) {
+		t.Errorf("export missing open header:\n%s", text)
+	}
+	imported, err := game.Import(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if !imported.Open {
+		t.Error("imported game is not open")
+	}
+
+	if strings.Contains(game.NewGameFromSeed(42).Export(), "Open") {
+		t.Error("a regular game was exported with an open header")
+	}
+}
+
+func TestMove_String(t *testing.T) {
+	tests := []struct {
+		move game.Move
+		want string
+	}{
+		{game.Move{Kind: game.MoveDraw}, "D"},
+		{game.Move{Kind: game.MoveRecycle}, "R"},
+		{game.Move{Kind: game.MoveTransfer, From: game.WastePile, To: game.TableauPile3}, "W→T3"},
+		{game.Move{Kind: game.MoveTransfer, From: game.TableauPile5, Index: 2, To: game.FoundationPile1}, "T5:2→F1"},
+	}
+	for _, tt := range tests {
+		if got := tt.move.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestImport_IllegalMove(t *testing.T) {
+	text := "[Variant \"Klondike\"]\n[Seed \"42\"]\n\n1. D 2. F1->T1\n"
+
+	_, err := game.Import(strings.NewReader(text))
+
+	var nerr *game.NotationError
+	if !errors.As(err, &nerr) {
+		t.Fatalf("Import() error = %v, want *NotationError", err)
+	}
+	if nerr.Line != 4 || nerr.Column != 9 {
+		t.Errorf("error at line %d column %d, want line 4 column 9", nerr.Line, nerr.Column)
+	}
+}
+
+func TestImport_MissingSeed(t *testing.T) {
+	if _, err := game.Import(strings.NewReader("[Variant \"Klondike\"]\n\nD\n")); err == nil {
+		t.Error("expected error for missing seed")
+	}
+}
@@ -0,0 +1,171 @@
+package game_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestParseRules_RoundTrip(t *testing.T) {
+	r := game.Rules{DrawCount: 3, Redeals: 2, AnyCardOnEmpty: true, StockToTableau: true}
+
+	got, err := game.ParseRules(r.String())
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if got != r {
+		t.Errorf("ParseRules(%q) = %+v, want %+v", r.String(), got, r)
+	}
+}
+
+func TestParseRules_Errors(t *testing.T) {
+	for _, s := range []string{"draw=2", "redeals=-3", "empty=queens", "partial=maybe", "speed=fast", "draw"} {
+		if _, err := game.ParseRules(s); err == nil {
+			t.Errorf("ParseRules(%q) should fail", s)
+		}
+	}
+}
+
+func TestRules_With(t *testing.T) {
+	base := game.Rules{DrawCount: 3, Redeals: 2, PartialStacks: true}
+	got, err := base.With("empty=any redeals=unlimited")
+	if err != nil {
+		t.Fatalf("With() error = %v", err)
+	}
+	want := game.Rules{DrawCount: 3, Redeals: game.UnlimitedRedeals, AnyCardOnEmpty: true, PartialStacks: true}
+	if got != want {
+		t.Errorf("With() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRules_EmptyColumnIsConsistent(t *testing.T) {
+	// Non-Kings are refused from every source by default, and allowed from every
+	// source with AnyCardOnEmpty.
+	sources := map[string]func(g *game.Game) int{
+		"Waste": func(g *game.Game) int {
+			g.Waste.Push(&game.Card{Rank: game.Queen, Suit: game.Hearts, FaceUp: true})
+			return game.WastePile
+		},
+		"Tableau": func(g *game.Game) int {
+			g.Tableaus[1].Push(&game.Card{Rank: game.Queen, Suit: game.Hearts, FaceUp: true})
+			return game.TableauPile2
+		},
+		"Foundation": func(g *game.Game) int {
+			g.Foundations[0].Push(&game.Card{Rank: game.Ace, Suit: game.Spades, FaceUp: true})
+			return game.FoundationPile1
+		},
+	}
+	for name, setup := range sources {
+		for _, anyCard := range []bool{false, true} {
+			var src int
+			g := setupGameWithSpecificCards(t, func(g *game.Game) {
+				g.Rules.AnyCardOnEmpty = anyCard
+				src = setup(g)
+			})
+			err := g.TryMove(src, 0, game.TableauPile1)
+			if anyCard && err != nil {
+				t.Errorf("%s with any card allowed: got %v", name, err)
+			}
+			if !anyCard && !errors.Is(err, game.ErrKingOnly) {
+				t.Errorf("%s with Kings only: got %v, want ErrKingOnly", name, err)
+			}
+		}
+	}
+}
+
+func TestRules_FoundationLocked(t *testing.T) {
+	g := setupGameWithSpecificCards(t, func(g *game.Game) {
+		g.Rules.FoundationToTableau = false
+		g.Foundations[0].Push(&game.Card{Rank: game.Ace, Suit: game.Spades, FaceUp: true})
+		g.Tableaus[0].Push(&game.Card{Rank: game.Two, Suit: game.Hearts, FaceUp: true})
+	})
+	if err := g.TryMove(game.FoundationPile1, 0, game.TableauPile1); !errors.Is(err, game.ErrFoundationLocked) {
+		t.Errorf("got %v, want ErrFoundationLocked", err)
+	}
+}
+
+func TestRules_PartialStacks(t *testing.T) {
+	setup := func(g *game.Game) {
+		g.Rules.PartialStacks = false
+		g.Tableaus[0].Push(&game.Card{Rank: game.Seven, Suit: game.Clubs, FaceUp: true})
+		g.Tableaus[0].Push(&game.Card{Rank: game.Six, Suit: game.Hearts, FaceUp: true})
+		g.Tableaus[0].Push(&game.Card{Rank: game.Five, Suit: game.Spades, FaceUp: true})
+		g.Tableaus[1].Push(&game.Card{Rank: game.Seven, Suit: game.Diamonds, FaceUp: true})
+	}
+	g := setupGameWithSpecificCards(t, setup)
+	if err := g.TryMove(game.TableauPile1, 1, game.TableauPile2); !errors.Is(err, game.ErrPartialStack) {
+		t.Errorf("partial run: got %v, want ErrPartialStack", err)
+	}
+}
+
+func TestRules_StockToTableau(t *testing.T) {
+	g := setupGameWithSpecificCards(t, func(g *game.Game) {
+		g.Stock.Push(&game.Card{Rank: game.King, Suit: game.Clubs})
+	})
+	if err := g.TryMove(game.StockPile, 0, game.TableauPile1); !errors.Is(err, game.ErrStockLocked) {
+		t.Errorf("default rules: got %v, want ErrStockLocked", err)
+	}
+
+	g.Rules.StockToTableau = true
+	if err := g.TryMove(game.StockPile, 0, game.TableauPile1); err != nil {
+		t.Fatalf("with stock play: got %v", err)
+	}
+	if !g.Tableaus[0].Peek().FaceUp {
+		t.Error("card played from the stock should be face up")
+	}
+}
+
+func TestRules_StockToFoundation(t *testing.T) {
+	for _, stockPlay := range []bool{false, true} {
+		g := setupGameWithSpecificCards(t, func(g *game.Game) {
+			g.Stock.Push(&game.Card{Rank: game.Ace, Suit: game.Clubs})
+			g.Rules.StockToTableau = stockPlay
+		})
+		want := game.ErrStockLocked
+		if stockPlay {
+			want = game.ErrIllegalMove
+		}
+		if err := g.TryMove(game.StockPile, 0, game.FoundationPile1); !errors.Is(err, want) {
+			t.Errorf("stock play %v: got %v, want %v", stockPlay, err, want)
+		}
+	}
+}
+
+func TestRules_DrawThreeAndRedeals(t *testing.T) {
+	g := game.NewGameWithRules(5, game.Rules{DrawCount: 3, Redeals: 1, PartialStacks: true})
+
+	g.DrawCard()
+	if len(g.Waste.Cards) != 3 || len(g.Stock.Cards) != 21 {
+		t.Fatalf("draw three: waste=%d stock=%d, want 3/21", len(g.Waste.Cards), len(g.Stock.Cards))
+	}
+
+	for len(g.Stock.Cards) > 0 {
+		g.DrawCard()
+	}
+	if err := g.Apply(game.Move{Kind: game.MoveRecycle}); err != nil {
+		t.Fatalf("first redeal: %v", err)
+	}
+	for len(g.Stock.Cards) > 0 {
+		g.DrawCard()
+	}
+	if err := g.Apply(game.Move{Kind: game.MoveRecycle}); !errors.Is(err, game.ErrNoRedeals) {
+		t.Errorf("second redeal: got %v, want ErrNoRedeals", err)
+	}
+}
+
+func TestNotation_KeepsRules(t *testing.T) {
+	rules := game.Rules{DrawCount: 3, Redeals: game.UnlimitedRedeals, PartialStacks: true}
+	g := game.NewGameWithRules(9, rules)
+	playSome(g, 30)
+
+	imported, err := game.Import(strings.NewReader(g.Export()))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if imported.Rules != rules {
+		t.Errorf("got rules %+v, want %+v", imported.Rules, rules)
+	}
+	sameBoard(t, g, imported)
+}
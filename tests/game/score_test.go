@@ -0,0 +1,120 @@
+package game_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestScore_Moves(t *testing.T) {
+	var s game.State
+	g := game.FromState(s)
+	g.Tableaus[0].Push(&game.Card{Suit: game.Clubs, Rank: game.Two})
+	g.Tableaus[0].Push(&game.Card{Suit: game.Hearts, Rank: game.Ace, FaceUp: true})
+	g.Waste.Push(&game.Card{Suit: game.Spades, Rank: game.King, FaceUp: true})
+
+	// Up to the foundation, turning over the card beneath
+	if err := g.TryMove(game.TableauPile1, 1, game.FoundationPile1); err != nil {
+		t.Fatal(err)
+	}
+	if want := game.PointsToFoundation + game.PointsTurnOver; g.Score != want {
+		t.Errorf("score = %d, want %d", g.Score, want)
+	}
+	if err := g.TryMove(game.WastePile, 0, game.TableauPile2); err != nil {
+		t.Fatal(err)
+	}
+	if want := game.PointsToFoundation + game.PointsTurnOver + game.PointsWasteToTableau; g.Score != want {
+		t.Errorf("score = %d, want %d", g.Score, want)
+	}
+
+	g.Undo()
+	g.Undo()
+	if g.Score != 0 {
+		t.Errorf("score after undoing every move = %d, want 0", g.Score)
+	}
+}
+
+func TestScore_Recycle(t *testing.T) {
+	g := game.NewGameFromSeed(3)
+	for len(g.Stock.Cards) > 0 {
+		g.DrawCard()
+	}
+	g.RecycleWaste()
+	if g.Score != game.PointsRecycleDrawOne {
+		t.Errorf("score = %d, want %d", g.Score, game.PointsRecycleDrawOne)
+	}
+}
+
+func TestVegasScore(t *testing.T) {
+	var s game.State
+	g := game.FromState(s)
+	if got := g.VegasScore(); got != -game.VegasAnte {
+		t.Errorf("score of a new deal = %d, want %d", got, -game.VegasAnte)
+	}
+	g.Waste.Push(&game.Card{Suit: game.Hearts, Rank: game.Ace, FaceUp: true})
+	if err := g.TryMove(game.WastePile, 0, game.FoundationPile1); err != nil {
+		t.Fatal(err)
+	}
+	if want := game.VegasCard - game.VegasAnte; g.VegasScore() != want {
+		t.Errorf("score = %d, want %d", g.VegasScore(), want)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	g := game.NewGameFromSeed(5)
+	hidden := *g.Tableaus[6].Cards[2]
+	c, err := g.Peek(game.TableauPile7, 2)
+	if err != nil {
+		t.Fatalf("Peek() error = %v", err)
+	}
+	if c != hidden || g.Tableaus[6].Cards[2].FaceUp {
+		t.Errorf("peek showed %v and left it face up = %v, want %v still face down", c, g.Tableaus[6].Cards[2].FaceUp, hidden)
+	}
+	if next, _ := g.Peek(game.StockPile, 0); next != *g.Stock.Peek() {
+		t.Errorf("stock peek showed %v, want the next card %v", next, *g.Stock.Peek())
+	}
+	if g.Peeks != 2 || g.Score != 2*game.PointsPeek {
+		t.Errorf("peeks = %d, score = %d, want 2 and %d", g.Peeks, g.Score, 2*game.PointsPeek)
+	}
+
+	// Undoing a move does not refund a peek made after it
+	g.DrawCard()
+	g.Peek(game.TableauPile7, 0)
+	g.Undo()
+	if g.Score != 3*game.PointsPeek {
+		t.Errorf("score after undo = %d, want %d", g.Score, 3*game.PointsPeek)
+	}
+
+	for _, tc := range []struct{ pile, index int }{
+		{game.TableauPile7, 6}, // Face up
+		{game.WastePile, 0},
+		{game.FoundationPile1, 0},
+	} {
+		if _, err := g.Peek(tc.pile, tc.index); !errors.Is(err, game.ErrNotHidden) {
+			t.Errorf("Peek(%s, %d) error = %v, want ErrNotHidden", game.PileName(tc.pile), tc.index, err)
+		}
+	}
+	if _, err := game.NewOpenGame(5, game.DefaultRules()).Peek(game.TableauPile7, 2); !errors.Is(err, game.ErrNotHidden) {
+		t.Errorf("peek in an open game error = %v, want ErrNotHidden", err)
+	}
+}
+
+func TestPeek_InGameRecord(t *testing.T) {
+	g := game.NewGameFromSeed(5)
+	g.Peek(game.TableauPile7, 2)
+	g.DrawCard()
+
+	text := g.Export()
+	if !strings.Contains(text, `[Peeks "1"]`) {
+		t.Errorf("export missing peeks header:\n%s", text)
+	}
+	imported, err := game.Import(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if imported.Peeks != 1 || imported.Score != g.Score {
+		t.Errorf("imported peeks = %d, score = %d, want 1 and %d", imported.Peeks, imported.Score, g.Score)
+	}
+}
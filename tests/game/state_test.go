@@ -0,0 +1,63 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestCardCode_RoundTrip(t *testing.T) {
+	for _, c := range game.NewDeck() {
+		for _, up := range []bool{false, true} {
+			card := game.Card{Suit: c.Suit, Rank: c.Rank, FaceUp: up}
+			if got := game.CodeOf(card).Card(); got != card {
+				t.Errorf("CodeOf(%+v).Card() = %+v", card, got)
+			}
+		}
+	}
+}
+
+func TestState_RoundTrip(t *testing.T) {
+	g := game.NewGameFromSeed(11)
+	playSome(g, 40)
+
+	s := g.State()
+	sameBoard(t, g, game.FromState(s))
+	if restored := game.FromState(s).State(); !restored.Equal(s) {
+		t.Error("state changed after a round trip through Game")
+	}
+}
+
+func TestState_Hash(t *testing.T) {
+	g := game.NewGameFromSeed(11)
+	a, b := g.State(), g.State()
+	if a.Hash() != b.Hash() || !a.Equal(b) {
+		t.Fatal("identical boards must be equal and hash equally")
+	}
+
+	g.DrawCard()
+	c := g.State()
+	if c.Equal(a) {
+		t.Error("states should differ after a draw")
+	}
+	if c.Hash() == a.Hash() {
+		t.Error("hash should change after a draw")
+	}
+}
+
+func TestGame_Clone(t *testing.T) {
+	g := game.NewGameFromSeed(11)
+	playSome(g, 10)
+	c := g.Clone()
+	before := g.State()
+
+	// Play on the clone only
+	playSome(c, 20)
+
+	if after := g.State(); !after.Equal(before) {
+		t.Error("moves on a clone changed the original game")
+	}
+	if len(c.History) == len(g.History) {
+		t.Error("clone history should be independent")
+	}
+}
@@ -0,0 +1,98 @@
+package game_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestUndo_RestoresBoard(t *testing.T) {
+	g := game.NewGameFromSeed(11)
+	if g.CanUndo() || g.Undo() {
+		t.Fatal("a fresh deal should have nothing to undo")
+	}
+
+	var states []game.State
+	for i := 0; i < 30; i++ {
+		states = append(states, g.State())
+		if len(g.Stock.Cards) == 0 {
+			g.RecycleWaste()
+		} else {
+			g.DrawCard()
+		}
+	}
+
+	var undone int
+	g.Subscribe(func(e game.Event) {
+		if _, ok := e.(game.MoveUndone); ok {
+			undone++
+		}
+	})
+	for i := len(states) - 1; i >= 0; i-- {
+		if !g.Undo() {
+			t.Fatalf("Undo failed with %d moves left", i+1)
+		}
+		if g.State() != states[i] {
+			t.Fatalf("board after undoing to move %d differs", i)
+		}
+		if len(g.History) != i {
+			t.Fatalf("History has %d moves, want %d", len(g.History), i)
+		}
+	}
+	if undone != len(states) {
+		t.Errorf("got %d MoveUndone events, want %d", undone, len(states))
+	}
+	if g.Recycles != 0 {
+		t.Errorf("Recycles = %d after undoing everything, want 0", g.Recycles)
+	}
+}
+
+func TestUndo_FailedMoveIsNotRecorded(t *testing.T) {
+	g := game.NewGameFromSeed(11)
+	if err := g.TryMove(game.TableauPile1, 0, game.FoundationPile1); err == nil {
+		t.Fatal("7♦ should not go to an empty foundation")
+	}
+	if g.CanUndo() {
+		t.Error("an illegal move left an undo entry")
+	}
+}
+
+func TestGame_String(t *testing.T) {
+	g := game.NewGameFromSeed(1)
+	text := g.String()
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("got %d lines, want 9:\n%s", len(lines), text)
+	}
+	if lines[0] != "S: [24]  W: --" {
+		t.Errorf("stock line = %q", lines[0])
+	}
+	if lines[8] != "T7: ## ## ## ## ## ## 8♠" {
+		t.Errorf("T7 line = %q", lines[8])
+	}
+}
+
+func TestUndo_FlippedCardIsSeen(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		g := game.NewGameFromSeed(seed)
+		for _, m := range g.LegalMoves() {
+			if m.Kind != game.MoveTransfer || m.From < game.TableauPile1 || m.Index == 0 {
+				continue
+			}
+			under := *g.GetPile(m.From).Cards[m.Index-1]
+			if g.Seen(under) {
+				t.Fatalf("%v counts as seen before it was turned up", under)
+			}
+			if err := g.Apply(m); err != nil {
+				t.Fatal(err)
+			}
+			g.Undo()
+			if !g.Seen(under) {
+				t.Errorf("%v was turned up, then hidden by undo, but does not count as seen", under)
+			}
+			return
+		}
+	}
+	t.Skip("no deal with a move that turns a card up")
+}
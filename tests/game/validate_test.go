@@ -0,0 +1,136 @@
+package game_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+)
+
+func TestValidate_NewGame(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		if err := game.NewGameFromSeed(seed).Validate(); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+func TestValidate_Violations(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(g *game.Game)
+	}{
+		{"MissingCard", func(g *game.Game) { g.Stock.Pop() }},
+		{"DuplicateCard", func(g *game.Game) {
+			c := *g.Tableaus[0].Peek()
+			g.Stock.Cards[0] = &c
+		}},
+		{"FaceDownAboveFaceUp", func(g *game.Game) {
+			g.Tableaus[6].Cards[2].FaceUp = true
+		}},
+		{"FaceUpInStock", func(g *game.Game) { g.Stock.Peek().FaceUp = true }},
+		{"FoundationWrongStart", func(g *game.Game) {
+			c := g.Stock.Pop()
+			c.FaceUp = true
+			c.Rank = game.Two
+			g.Foundations[0].Push(c)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := game.NewGameFromSeed(1)
+			tt.corrupt(g)
+			if err := g.Validate(); err == nil {
+				t.Error("Validate() = nil, want an invariant violation")
+			}
+		})
+	}
+}
+
+// rulesFromByte picks a house-rule combination from the bits of b.
+func rulesFromByte(b byte) game.Rules {
+	r := game.DefaultRules()
+	if b&1 != 0 {
+		r.DrawCount = 3
+	}
+	if b&2 != 0 {
+		r.Redeals = int(b>>6) % 3
+	}
+	r.AnyCardOnEmpty = b&4 != 0
+	r.FoundationToTableau = b&8 == 0
+	r.PartialStacks = b&16 == 0
+	r.StockToTableau = b&32 != 0
+	return r
+}
+
+// playOps drives g with byte-coded actions, four bytes each, checking the board
+// invariants after every one.
+func playOps(t *testing.T, g *game.Game, ops []byte) {
+	t.Helper()
+	for i := 0; i+3 < len(ops); i += 4 {
+		op := "move"
+		switch ops[i] % 8 {
+		case 0:
+			op = "draw"
+			g.DrawCard()
+		case 1:
+			op = "recycle"
+			g.RecycleWaste()
+		default:
+			src := int(ops[i+1]) % 13
+			dst := int(ops[i+3]) % 13
+			idx := pickIndex(g, src, ops[i+2])
+			// With the top bit of the last byte set, search from (src, dst) for the
+			// first legal move so that long games get played out
+			for n := 0; n < 13*13 && ops[i+3]&0x80 != 0 && g.CheckMove(src, idx, dst) != nil; n++ {
+				if dst = (dst + 1) % 13; dst == 0 {
+					src = (src + 1) % 13
+				}
+				idx = pickIndex(g, src, ops[i+2])
+			}
+			g.Move(src, idx, dst)
+		}
+		if err := g.Validate(); err != nil {
+			t.Fatalf("after op %d (%s), history %v:\n%v", i/4, op, g.History, err)
+		}
+	}
+}
+
+// pickIndex chooses a card in pile src from b: the top card or whole face-up
+// run when the top bit is set, otherwise any position.
+func pickIndex(g *game.Game, src int, b byte) int {
+	pile := g.GetPile(src)
+	if len(pile.Cards) == 0 {
+		return -1
+	}
+	if b&0x80 == 0 {
+		return int(b&0x7f) % len(pile.Cards)
+	}
+	idx := len(pile.Cards) - 1
+	for src >= game.TableauPile1 && idx > 0 && pile.Cards[idx-1].FaceUp && b&1 == 0 {
+		idx--
+	}
+	return idx
+}
+
+func TestValidate_RandomPlay(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		ops := make([]byte, 4*300)
+		rng.Read(ops)
+		g := game.NewGameWithRules(seed, rulesFromByte(byte(seed)))
+		playOps(t, g, ops)
+	}
+}
+
+func FuzzMove(f *testing.F) {
+	f.Add(int64(1), byte(0), []byte{0, 0, 0, 0, 2, 6, 0x80, 7, 1, 0, 0, 0})
+	f.Add(int64(42), byte(0x3f), []byte{3, 12, 0x80, 2, 0, 0, 0, 0, 4, 1, 0, 6})
+	f.Add(int64(7), byte(0x05), []byte{5, 0, 0, 6, 6, 11, 0x83, 12})
+
+	f.Fuzz(func(t *testing.T, seed int64, rules byte, ops []byte) {
+		g := game.NewGameWithRules(seed, rulesFromByte(rules))
+		playOps(t, g, ops)
+	})
+}
@@ -0,0 +1,78 @@
+package headless_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/headless"
+)
+
+func TestRun_Commands(t *testing.T) {
+	g := game.NewGameFromSeed(1)
+	script := `
+# comments and blank lines are skipped
+draw
+d
+undo
+D
+`
+	var out strings.Builder
+	failed, err := headless.Run(g, strings.NewReader(script), &out)
+	if err != nil || failed != 0 {
+		t.Fatalf("Run = %d, %v; output:\n%s", failed, err, out.String())
+	}
+	if len(g.History) != 2 {
+		t.Errorf("History has %d moves, want 2", len(g.History))
+	}
+	if got := strings.Count(out.String(), "S: ["); got != 5 {
+		t.Errorf("board printed %d times, want 5 (start plus each command)", got)
+	}
+	if !strings.HasSuffix(out.String(), g.String()) {
+		t.Errorf("output does not end with the final board:\n%s", out.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	g := game.NewGameFromSeed(1)
+	script := "move T1 F1\nfly\nmove W\nundo\nquit\ndraw\n"
+	var out strings.Builder
+	failed, err := headless.Run(g, strings.NewReader(script), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed != 4 {
+		t.Errorf("failed = %d, want 4; output:\n%s", failed, out.String())
+	}
+	if !strings.Contains(out.String(), "error: line 2: unknown command \"fly\"") {
+		t.Errorf("missing line number in errors:\n%s", out.String())
+	}
+	if len(g.History) != 0 {
+		t.Errorf("commands after quit ran: History = %v", g.History)
+	}
+}
+
+func TestRun_Move(t *testing.T) {
+	// Seed 1 deals the 4♦ onto T2 and the 5♠ onto T3
+	g := game.NewGameFromSeed(1)
+	var out strings.Builder
+	failed, err := headless.Run(g, strings.NewReader("move T2 T3\n"), &out)
+	if err != nil || failed != 0 {
+		t.Fatalf("Run = %d, %v; output:\n%s", failed, err, out.String())
+	}
+	if top := g.Tableaus[2].Peek(); top == nil || top.Rank != game.Four || top.Suit != game.Diamonds {
+		t.Errorf("T3 top = %v, want 4♦", top)
+	}
+}
+
+func TestRun_MoveLineIsAllOrNothing(t *testing.T) {
+	g := game.NewGameFromSeed(1)
+	var out strings.Builder
+	failed, err := headless.Run(g, strings.NewReader("D D T1->F1\n"), &out)
+	if err != nil || failed != 1 {
+		t.Fatalf("Run = %d, %v; output:\n%s", failed, err, out.String())
+	}
+	if len(g.History) != 0 {
+		t.Errorf("History = %v, want no moves from a line with an illegal move", g.History)
+	}
+}
@@ -0,0 +1,60 @@
+package records_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/records"
+)
+
+func TestSaveList(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), records.DirName)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	older := game.NewGameFromSeed(3)
+	older.DrawCard()
+	if _, err := records.Save(dir, older, now); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	newer := game.NewGameFromSeed(4)
+	path, err := records.Save(dir, newer, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// Files that are not games are skipped
+	if err := os.WriteFile(filepath.Join(dir, "junk.sol"), []byte("not a game"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := records.List(dir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if e := entries[0]; e.Path != path || e.Seed != 4 || !e.Saved.Equal(now.Add(time.Hour)) {
+		t.Errorf("newest entry = %+v", e)
+	}
+	if e := entries[1]; e.Seed != 3 || e.Moves != 1 || e.Won {
+		t.Errorf("oldest entry = %+v, want seed 3 with one move", e)
+	}
+
+	g, err := records.Load(entries[1].Path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if g.State() != older.State() {
+		t.Error("loaded game does not match the saved one")
+	}
+}
+
+func TestList_MissingDir(t *testing.T) {
+	entries, err := records.List(filepath.Join(t.TempDir(), "none"))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("List() = %v, %v; want no games", entries, err)
+	}
+}
@@ -0,0 +1,63 @@
+package solver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+func TestPar_NoLongerThanSolve(t *testing.T) {
+	g := game.NewGameFromSeed(3)
+	first := solver.Solve(context.Background(), g, solver.Options{MaxNodes: 20000})
+	if first.Status != solver.Solved {
+		t.Skipf("seed 3 not solved within budget: %v", first.Status)
+	}
+	r := solver.Par(context.Background(), g, solver.ParOptions{Options: solver.Options{MaxNodes: 40000}})
+	if r.Status != solver.Solved {
+		t.Fatalf("status = %v, want solved", r.Status)
+	}
+	if len(r.Moves) > len(first.Moves) {
+		t.Errorf("par of %d moves is longer than the first solution's %d", len(r.Moves), len(first.Moves))
+	}
+	if r.Nodes > 40000 {
+		t.Errorf("searched %d nodes, over the budget of 40000", r.Nodes)
+	}
+	for _, m := range r.Moves {
+		if err := g.Apply(m); err != nil {
+			t.Fatalf("par move %s: %v", m, err)
+		}
+	}
+	if !g.IsWon {
+		t.Error("par solution does not win")
+	}
+}
+
+func TestPar_ProvenEndgame(t *testing.T) {
+	// Every suit is up to Jack. The Queens sit on the wrong Kings, so the
+	// shortest win is one move per card left.
+	var s game.State
+	g := game.FromState(s)
+	for i, suit := range []game.Suit{game.Hearts, game.Diamonds, game.Clubs, game.Spades} {
+		for r := game.Ace; r <= game.Jack; r++ {
+			g.Foundations[i].Push(&game.Card{Suit: suit, Rank: r, FaceUp: true})
+		}
+	}
+	g.Tableaus[0].Push(&game.Card{Suit: game.Hearts, Rank: game.King, FaceUp: true})
+	g.Tableaus[0].Push(&game.Card{Suit: game.Spades, Rank: game.Queen, FaceUp: true})
+	g.Tableaus[1].Push(&game.Card{Suit: game.Spades, Rank: game.King, FaceUp: true})
+	g.Tableaus[1].Push(&game.Card{Suit: game.Diamonds, Rank: game.Queen, FaceUp: true})
+	g.Tableaus[2].Push(&game.Card{Suit: game.Clubs, Rank: game.King, FaceUp: true})
+	g.Tableaus[2].Push(&game.Card{Suit: game.Hearts, Rank: game.Queen, FaceUp: true})
+	g.Tableaus[3].Push(&game.Card{Suit: game.Diamonds, Rank: game.King, FaceUp: true})
+	g.Tableaus[3].Push(&game.Card{Suit: game.Clubs, Rank: game.Queen, FaceUp: true})
+
+	r := solver.Par(context.Background(), g, solver.ParOptions{})
+	if r.Status != solver.Solved || !r.Proven {
+		t.Fatalf("status = %v, proven = %v, want a proven solution", r.Status, r.Proven)
+	}
+	if len(r.Moves) != 8 {
+		t.Errorf("par = %d moves (%v), want 8", len(r.Moves), r.Moves)
+	}
+}
@@ -0,0 +1,115 @@
+package solver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/game"
+	"github.com/solitaire-tui/solitaire-tui/internal/solver"
+)
+
+func TestSolve_SolutionWins(t *testing.T) {
+	solved := 0
+	for seed := int64(1); seed <= 5; seed++ {
+		g := game.NewGameFromSeed(seed)
+		r := solver.Solve(context.Background(), g, solver.Options{MaxNodes: 20000})
+		if len(g.History) != 0 {
+			t.Fatalf("seed %d: Solve modified the game", seed)
+		}
+		if r.Status != solver.Solved {
+			continue
+		}
+		solved++
+		for _, m := range r.Moves {
+			if err := g.Apply(m); err != nil {
+				t.Fatalf("seed %d: solution move %s: %v", seed, m, err)
+			}
+		}
+		if !g.IsWon {
+			t.Errorf("seed %d: solution of %d moves does not win", seed, len(r.Moves))
+		}
+	}
+	if solved == 0 {
+		t.Error("no deal among seeds 1-5 was solved")
+	}
+}
+
+func TestSolve_FromMidGame(t *testing.T) {
+	g := game.NewGameFromSeed(2)
+	g.DrawCard()
+	g.DrawCard()
+	r := solver.Solve(context.Background(), g, solver.Options{MaxNodes: 20000})
+	if r.Status != solver.Solved {
+		t.Skipf("seed 2 not solved within budget: %v", r.Status)
+	}
+	for _, m := range r.Moves {
+		g.Apply(m)
+	}
+	if !g.IsWon {
+		t.Error("solution from a mid-game position does not win")
+	}
+}
+
+func TestSolve_NoWinFound(t *testing.T) {
+	// Three Hearts on an otherwise empty board can never fill the foundations,
+	// so the search must run dry rather than out of budget.
+	var s game.State
+	g := game.FromState(s)
+	g.Tableaus[0].Push(&game.Card{Suit: game.Hearts, Rank: game.Six})
+	g.Tableaus[0].Push(&game.Card{Suit: game.Hearts, Rank: game.Seven})
+	g.Tableaus[0].Push(&game.Card{Suit: game.Hearts, Rank: game.Five, FaceUp: true})
+	r := solver.Solve(context.Background(), g, solver.Options{})
+	if r.Status != solver.NoWinFound {
+		t.Errorf("status = %v, want no win found", r.Status)
+	}
+}
+
+func TestSolve_BudgetAndCancel(t *testing.T) {
+	g := game.NewGameFromSeed(1)
+	if r := solver.Solve(context.Background(), g, solver.Options{MaxNodes: 5}); r.Status != solver.Unknown || r.Nodes != 5 {
+		t.Errorf("tiny budget gave %v after %d nodes, want unknown", r.Status, r.Nodes)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if r := solver.Solve(ctx, game.NewGameFromSeed(9), solver.Options{}); r.Status == solver.NoWinFound {
+		t.Error("a cancelled search claimed no win exists")
+	}
+}
+
+func TestSolve_Progress(t *testing.T) {
+	var calls []int
+	r := solver.Solve(context.Background(), game.NewGameFromSeed(1), solver.Options{
+		MaxNodes: 5000,
+		Progress: func(nodes int) { calls = append(calls, nodes) },
+	})
+	if want := r.Nodes / solver.ProgressInterval; len(calls) != want {
+		t.Errorf("got %d progress calls for %d nodes, want %d", len(calls), r.Nodes, want)
+	}
+	for i, n := range calls {
+		if n != (i+1)*solver.ProgressInterval {
+			t.Errorf("progress call %d reported %d nodes", i, n)
+		}
+	}
+}
+
+func TestSafeMove(t *testing.T) {
+	var s game.State
+	g := game.FromState(s)
+	g.Foundations[0].Push(&game.Card{Suit: game.Hearts, Rank: game.Ace, FaceUp: true})
+	g.Foundations[0].Push(&game.Card{Suit: game.Hearts, Rank: game.Two, FaceUp: true})
+	g.Foundations[1].Push(&game.Card{Suit: game.Clubs, Rank: game.Ace, FaceUp: true})
+	g.Foundations[1].Push(&game.Card{Suit: game.Clubs, Rank: game.Two, FaceUp: true})
+	g.Foundations[2].Push(&game.Card{Suit: game.Spades, Rank: game.Ace, FaceUp: true})
+	g.Tableaus[0].Push(&game.Card{Suit: game.Hearts, Rank: game.Three, FaceUp: true})
+
+	// The Two of Spades could still go on the Three of Hearts
+	if m, ok := solver.SafeMove(g); ok {
+		t.Fatalf("SafeMove = %s, want none while a black Two is out", m)
+	}
+	g.Foundations[2].Push(&game.Card{Suit: game.Spades, Rank: game.Two, FaceUp: true})
+	m, ok := solver.SafeMove(g)
+	if !ok || m.From != game.TableauPile1 || m.To != game.FoundationPile1 {
+		t.Errorf("SafeMove = %s, %v; want the Three of Hearts up", m, ok)
+	}
+}
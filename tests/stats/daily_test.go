@@ -0,0 +1,41 @@
+package stats_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/stats"
+)
+
+func TestDailyLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stats.DailyFileName)
+	l, err := stats.LoadDaily(path)
+	if err != nil {
+		t.Fatalf("LoadDaily() error = %v", err)
+	}
+	day := time.Date(2026, 1, 17, 8, 0, 0, 0, time.UTC)
+
+	l.Attempt(day)
+	if e := l.Entry(day); e == nil || e.Solved {
+		t.Fatalf("expected an unsolved entry after Attempt, got %+v", e)
+	}
+
+	l.Solve(day, 5*time.Minute, 130)
+	l.Solve(day, 7*time.Minute, 150) // Worse result is not kept
+	if err := l.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := stats.LoadDaily(path)
+	if err != nil {
+		t.Fatalf("LoadDaily() error = %v", err)
+	}
+	e := loaded.Entry(day)
+	if e == nil || !e.Solved || e.Moves != 130 || e.Secs != 300 {
+		t.Errorf("got %+v, want solved in 130 moves and 300s", e)
+	}
+	if loaded.Entry(day.AddDate(0, 0, 1)) != nil {
+		t.Errorf("unexpected entry for another day")
+	}
+}
@@ -0,0 +1,137 @@
+package stats_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/solitaire-tui/solitaire-tui/internal/stats"
+)
+
+func TestStreaks(t *testing.T) {
+	s, err := stats.Load(filepath.Join(t.TempDir(), stats.FileName))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	key := stats.ModeKey("Klondike", 1)
+
+	// Win, win, abandon, win
+	s.Begin(key)
+	s.Win(key, 3*time.Minute, 120)
+	s.Begin(key)
+	s.Win(key, 2*time.Minute, 140)
+	s.Begin(key)
+	s.Begin(key)
+	s.Win(key, 4*time.Minute, 100)
+
+	r := s.Mode(key)
+	if r.Played != 4 || r.Won != 3 || r.Lost() != 1 {
+		t.Errorf("got played=%d won=%d lost=%d, want 4/3/1", r.Played, r.Won, r.Lost())
+	}
+	if r.CurrentStreak != 1 || r.LongestStreak != 2 {
+		t.Errorf("got streak=%d longest=%d, want 1/2", r.CurrentStreak, r.LongestStreak)
+	}
+	if r.FastestWinSecs != 120 || r.FewestMoves != 100 {
+		t.Errorf("got fastest=%d fewest=%d, want 120/100", r.FastestWinSecs, r.FewestMoves)
+	}
+	if r.AverageWinTime() != 3*time.Minute {
+		t.Errorf("got average %v, want 3m", r.AverageWinTime())
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", stats.FileName)
+	s, _ := stats.Load(path)
+	s.Begin("Klondike draw-1")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := stats.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Mode("Klondike draw-1").Played != 1 {
+		t.Errorf("played count not persisted")
+	}
+}
+
+func TestWinAgainstPar_Persisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stats.FileName)
+	s, _ := stats.Load(path)
+	key := stats.ModeKey("Klondike", 1)
+	s.Begin(key)
+	s.Win(key, time.Minute, 90)
+	s.WinAgainstPar(key, 90, 90)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := stats.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.Mode(key).ParOrBetter; got != 1 {
+		t.Errorf("par or better = %d, want 1", got)
+	}
+}
+
+func TestWinAgainstPar(t *testing.T) {
+	for _, tt := range []struct {
+		moves, par int
+		want       int
+	}{
+		{moves: 89, par: 90, want: 1},
+		{moves: 90, par: 90, want: 1}, // Matching a proven par is the best possible
+		{moves: 91, par: 90, want: 0},
+	} {
+		s, _ := stats.Load("")
+		key := stats.ModeKey("Klondike", 1)
+		s.WinAgainstPar(key, tt.moves, tt.par)
+		if got := s.Mode(key).ParOrBetter; got != tt.want {
+			t.Errorf("WinAgainstPar(%d moves, par %d) counted %d, want %d", tt.moves, tt.par, got, tt.want)
+		}
+	}
+}
+
+func TestWinScore_PeekedWinsApart(t *testing.T) {
+	s, _ := stats.Load("")
+	key := stats.ModeKey("Klondike", 1)
+	s.WinScore(key, 600, 0)
+	s.WinScore(key, 900, 2)
+	s.WinScore(key, 550, 0)
+
+	r := s.Mode(key)
+	if r.BestScore != 600 || r.PeekedWins != 1 {
+		t.Errorf("best score = %d, peeked wins = %d, want 600 and 1", r.BestScore, r.PeekedWins)
+	}
+}
+
+func TestResign(t *testing.T) {
+	s, _ := stats.Load("")
+	key := stats.ModeKey("Klondike", 3)
+	s.Begin(key)
+	s.Win(key, time.Minute, 100)
+	s.Begin(key)
+	s.Resign(key, 10)
+	s.Begin(key)
+	s.Resign(key, 5)
+
+	r := s.Mode(key)
+	if r.Lost() != 2 || r.Resigned != 2 || r.CurrentStreak != 0 {
+		t.Errorf("lost = %d, resigned = %d, streak = %d, want 2, 2, 0", r.Lost(), r.Resigned, r.CurrentStreak)
+	}
+	if got := r.AverageResignedCards(); got != 7.5 {
+		t.Errorf("average resigned cards = %v, want 7.5", got)
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stats.FileName)
+	os.WriteFile(path, []byte("{not json"), 0o644)
+
+	if _, err := stats.Load(path); err == nil {
+		t.Error("expected error for corrupt statistics file")
+	}
+}